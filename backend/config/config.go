@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 	"github.com/joho/godotenv"
@@ -12,13 +13,15 @@ import (
 type (
 	// Config -.
 	Config struct {
-		App   `yaml:"app"`
-		HTTP  `yaml:"http"`
-		PG    `yaml:"postgres"`
-		S3    `yaml:"s3"`
-		RMQ   `yaml:"rmq"`
-		JWT   `yaml:"jwt"`
-		Redis `yaml:"redis"`
+		App       `yaml:"app"`
+		HTTP      `yaml:"http"`
+		PG        `yaml:"postgres"`
+		S3        `yaml:"s3"`
+		RMQ       `yaml:"rmq"`
+		JWT       `yaml:"jwt"`
+		Redis     `yaml:"redis"`
+		Scheduler `yaml:"scheduler"`
+		Trash     `yaml:"trash"`
 	}
 
 	// App -.
@@ -61,6 +64,17 @@ type (
 		ADDRESS  string `env-required:"true"    env:"REDIS_ADDRESS"`
 		PASSWORD string `env-required:"true"    env:"REDIS_PASSWORD"`
 	}
+
+	// Scheduler publishes scheduled recipes.
+	Scheduler struct {
+		Interval time.Duration `env-default:"1m" yaml:"interval" env:"SCHEDULER_INTERVAL"`
+	}
+
+	// Trash keeps deleted items restorable for Retention and purges them every PurgeInterval.
+	Trash struct {
+		Retention     time.Duration `env-default:"720h" yaml:"retention"      env:"TRASH_RETENTION"`
+		PurgeInterval time.Duration `env-default:"1h"   yaml:"purge_interval" env:"TRASH_PURGE_INTERVAL"`
+	}
 )
 
 // NewConfig returns app config.
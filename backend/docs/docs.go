@@ -86,6 +86,50 @@ const docTemplate = `{
                 }
             }
         },
+        "/auth/restore": {
+            "post": {
+                "description": "Bring back a deleted account with its recipes and comments and sign in",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Restore user",
+                "operationId": "restore user",
+                "parameters": [
+                    {
+                        "description": "User params",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.UserLogin"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.AuthUser"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
         "/auth/signin": {
             "post": {
                 "description": "Sign in user",
@@ -195,6 +239,65 @@ const docTemplate = `{
                 }
             }
         },
+        "/labels": {
+            "get": {
+                "description": "Get all allergens and diets recipes can be labeled with",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "labels"
+                ],
+                "summary": "Get labels",
+                "operationId": "get labels",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.Labels"
+                        }
+                    }
+                }
+            }
+        },
+        "/labels/suggest": {
+            "post": {
+                "description": "Suggest allergens and diets by ingredients",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "labels"
+                ],
+                "summary": "Suggest labels",
+                "operationId": "suggest labels",
+                "parameters": [
+                    {
+                        "description": "ingredients",
+                        "name": "ingredients",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.LabelsSuggestion"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.Labels"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    }
+                }
+            }
+        },
         "/recipe": {
             "get": {
                 "description": "Get all recipe",
@@ -206,16 +309,45 @@ const docTemplate = `{
                 ],
                 "summary": "Get all recipe",
                 "operationId": "get all recipe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "metric",
+                            "imperial"
+                        ],
+                        "type": "string",
+                        "name": "units",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/entities.RecipeWithAuthor"
+                            "$ref": "#/definitions/entities.RecipeList"
+                        },
+                        "headers": {
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Count of all recipes"
                             }
                         }
                     },
+                    "400": {
+                        "description": "Bad Request"
+                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
@@ -248,10 +380,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/entities.RecipeWithAuthor"
-                            }
+                            "$ref": "#/definitions/entities.RecipeList"
                         }
                     },
                     "400": {
@@ -306,6 +435,47 @@ const docTemplate = `{
                 }
             }
         },
+        "/recipe/by-ingredients": {
+            "post": {
+                "description": "Rank recipes by how many of their ingredients are at hand, the ones with fewer\nmissing ingredients go first. The pantry of the signed in user is searched\ntogether with the given ingredients. An ingredient is at hand when one of the\nnames is a whole word of it, \"salt\" matches \"sea salt\" but not \"unsalted butter\".",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pantry"
+                ],
+                "summary": "Find recipes by ingredients",
+                "operationId": "find recipes by ingredients",
+                "parameters": [
+                    {
+                        "description": "search",
+                        "name": "search",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.IngredientSearch"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.RecipeMatchList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
         "/recipe/{id}": {
             "get": {
                 "description": "Get recipe",
@@ -317,6 +487,24 @@ const docTemplate = `{
                 ],
                 "summary": "Get recipe",
                 "operationId": "get recipe",
+                "parameters": [
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "servings",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "metric",
+                            "imperial"
+                        ],
+                        "type": "string",
+                        "name": "units",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -336,6 +524,61 @@ const docTemplate = `{
                 }
             }
         },
+        "/recipe/{id}/bookmark": {
+            "post": {
+                "description": "Save the recipe for later",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bookmarks"
+                ],
+                "summary": "Bookmark",
+                "operationId": "bookmark",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove the recipe from saved for later",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bookmarks"
+                ],
+                "summary": "Remove bookmark",
+                "operationId": "unbookmark",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
         "/recipe/{id}/comment": {
             "put": {
                 "description": "Update comment",
@@ -455,17 +698,41 @@ const docTemplate = `{
                 }
             }
         },
-        "/recipe/{id}/like": {
+        "/recipe/{id}/cooked": {
             "post": {
-                "description": "Like recipe",
+                "description": "Mark that the user cooked the recipe, today unless cooked_on is set,\nwith an optional note and photo",
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "likes"
+                    "cooked"
+                ],
+                "summary": "Mark recipe as cooked",
+                "operationId": "mark recipe as cooked",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Photo of the dish",
+                        "name": "photo",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2025-01-06",
+                        "name": "cooked_on",
+                        "in": "formData"
+                    },
+                    {
+                        "maxLength": 500,
+                        "type": "string",
+                        "example": "Added more garlic",
+                        "name": "note",
+                        "in": "formData"
+                    }
                 ],
-                "summary": "Like",
-                "operationId": "like",
                 "responses": {
                     "200": {
                         "description": "OK"
@@ -476,32 +743,41 @@ const docTemplate = `{
                     "401": {
                         "description": "Unauthorized"
                     },
+                    "404": {
+                        "description": "Not Found"
+                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/recipe/{id}/unlike": {
-            "post": {
-                "description": "Unlike recipe",
+        "/recipe/{id}/cooked/photos": {
+            "get": {
+                "description": "Get all the photos users took of the cooked recipe, the latest first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "likes"
+                    "cooked"
                 ],
-                "summary": "Unlike",
-                "operationId": "unlike",
+                "summary": "Get cook photos",
+                "operationId": "get cook photos",
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entities.CookLog"
+                            }
+                        }
                     },
                     "400": {
                         "description": "Bad Request"
                     },
-                    "401": {
-                        "description": "Unauthorized"
+                    "404": {
+                        "description": "Not Found"
                     },
                     "500": {
                         "description": "Internal Server Error"
@@ -509,75 +785,23 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/{login}": {
-            "get": {
-                "description": "Get user info",
+        "/recipe/{id}/fork": {
+            "post": {
+                "description": "Copy the recipe into your account as a draft to cook your own version of it.\nPhotos are shared with the original recipe and its author is notified.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "recipe"
                 ],
-                "summary": "Get user info",
-                "operationId": "get user info",
+                "summary": "Fork recipe",
+                "operationId": "fork recipe",
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/entities.JSONUserInfo"
-                        }
+                        "description": "OK"
                     },
-                    "404": {
-                        "description": "Not Found"
-                    },
-                    "500": {
-                        "description": "Internal Server Error"
-                    }
-                }
-            },
-            "put": {
-                "description": "Update user",
-                "consumes": [
-                    "multipart/form-data"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "user"
-                ],
-                "summary": "Update user",
-                "operationId": "update user",
-                "parameters": [
-                    {
-                        "type": "file",
-                        "description": "Icon",
-                        "name": "icon",
-                        "in": "formData"
-                    },
-                    {
-                        "maxLength": 1500,
-                        "type": "string",
-                        "name": "about",
-                        "in": "formData"
-                    },
-                    {
-                        "maxLength": 20,
-                        "minLength": 3,
-                        "type": "string",
-                        "name": "login",
-                        "in": "formData"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "login",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request"
+                    "400": {
+                        "description": "Bad Request"
                     },
                     "401": {
                         "description": "Unauthorized"
@@ -591,23 +815,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/{login}/icon": {
-            "get": {
-                "description": "Get user icon",
+        "/recipe/{id}/like": {
+            "post": {
+                "description": "Like recipe",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "likes"
                 ],
-                "summary": "Get icon",
-                "operationId": "get icon",
+                "summary": "Like",
+                "operationId": "like",
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/entities.UserIcon"
-                        }
+                        "description": "OK"
                     },
                     "400": {
                         "description": "Bad Request"
@@ -615,18 +836,15 @@ const docTemplate = `{
                     "401": {
                         "description": "Unauthorized"
                     },
-                    "404": {
-                        "description": "Not Found"
-                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/user/{login}/password": {
+        "/recipe/{id}/rating": {
             "put": {
-                "description": "Update user password",
+                "description": "Give the recipe from 1 to 5 stars with an optional review. Rating the recipe\nagain replaces the previous rating. Authors can't rate their own recipes.",
                 "consumes": [
                     "application/json"
                 ],
@@ -634,17 +852,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "ratings"
                 ],
-                "summary": "Update user password",
-                "operationId": "update user password",
+                "summary": "Rate recipe",
+                "operationId": "rate recipe",
                 "parameters": [
                     {
-                        "description": "User params",
-                        "name": "user",
+                        "description": "rating",
+                        "name": "rating",
                         "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/entities.UserPasswordUpdate"
+                            "$ref": "#/definitions/entities.RateRecipe"
                         }
                     }
                 ],
@@ -665,78 +884,17 @@ const docTemplate = `{
                         "description": "Internal Server Error"
                     }
                 }
-            }
-        },
-        "/user/{login}/recipe": {
-            "post": {
-                "description": "Create recipe",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+            },
+            "delete": {
+                "description": "Remove the rating and the review the user gave to the recipe",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "recipe"
-                ],
-                "summary": "Create recipe",
-                "operationId": "create recipe",
-                "parameters": [
-                    {
-                        "type": "file",
-                        "description": "Photos",
-                        "name": "photos",
-                        "in": "formData"
-                    },
-                    {
-                        "maxLength": 2500,
-                        "type": "string",
-                        "name": "about",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "maximum": 3,
-                        "minimum": 1,
-                        "enum": [
-                            1,
-                            2,
-                            3
-                        ],
-                        "type": "integer",
-                        "name": "complexity",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "maxLength": 1500,
-                        "type": "string",
-                        "name": "ingridients",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "maxLength": 2000,
-                        "type": "string",
-                        "name": "instructions",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "name": "need_time",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "maxLength": 50,
-                        "minLength": 3,
-                        "type": "string",
-                        "name": "title",
-                        "in": "formData",
-                        "required": true
-                    }
+                    "ratings"
                 ],
+                "summary": "Delete rating",
+                "operationId": "delete rating",
                 "responses": {
                     "200": {
                         "description": "OK"
@@ -747,87 +905,50 @@ const docTemplate = `{
                     "401": {
                         "description": "Unauthorized"
                     },
+                    "404": {
+                        "description": "Not Found"
+                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/user/{login}/recipe/{id}": {
-            "put": {
-                "description": "Update recipe",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+        "/recipe/{id}/ratings": {
+            "get": {
+                "description": "Get a page of the ratings and reviews of the recipe, the latest first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "recipe"
+                    "ratings"
                 ],
-                "summary": "Update recipe",
-                "operationId": "update recipe",
+                "summary": "Get recipe ratings",
+                "operationId": "get recipe ratings",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "Photos",
-                        "name": "photos",
-                        "in": "formData"
-                    },
-                    {
-                        "maxLength": 2500,
                         "type": "string",
-                        "name": "about",
-                        "in": "formData"
+                        "name": "cursor",
+                        "in": "query"
                     },
                     {
-                        "maximum": 3,
+                        "maximum": 100,
                         "minimum": 1,
-                        "enum": [
-                            1,
-                            2,
-                            3
-                        ],
                         "type": "integer",
-                        "name": "complexity",
-                        "in": "formData"
-                    },
-                    {
-                        "maxLength": 1500,
-                        "type": "string",
-                        "name": "ingridients",
-                        "in": "formData"
-                    },
-                    {
-                        "maxLength": 2000,
-                        "type": "string",
-                        "name": "instructions",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "name": "need_time",
-                        "in": "formData"
-                    },
-                    {
-                        "maxLength": 50,
-                        "minLength": 3,
-                        "type": "string",
-                        "name": "title",
-                        "in": "formData"
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.RatingList"
+                        }
                     },
                     "400": {
                         "description": "Bad Request"
                     },
-                    "401": {
-                        "description": "Unauthorized"
-                    },
                     "404": {
                         "description": "Not Found"
                     },
@@ -835,27 +956,32 @@ const docTemplate = `{
                         "description": "Internal Server Error"
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete recipe",
+            }
+        },
+        "/recipe/{id}/revisions": {
+            "get": {
+                "description": "Get previous versions of the recipe, the newest first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "recipe"
+                    "revisions"
                 ],
-                "summary": "Delete recipe",
-                "operationId": "delete recipe",
+                "summary": "Get recipe revisions",
+                "operationId": "get recipe revisions",
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entities.RecipeRevision"
+                            }
+                        }
                     },
                     "400": {
                         "description": "Bad Request"
                     },
-                    "401": {
-                        "description": "Unauthorized"
-                    },
                     "404": {
                         "description": "Not Found"
                     },
@@ -865,26 +991,44 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/{login}/subscribe": {
-            "post": {
-                "description": "Subscribe to user",
+        "/recipe/{id}/revisions/diff": {
+            "get": {
+                "description": "Get fields changed between two revisions. Without \"to\" the revision is compared with the current recipe. Photos are not compared.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "subscription"
+                    "revisions"
+                ],
+                "summary": "Diff recipe revisions",
+                "operationId": "diff recipe revisions",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "to",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Subscribe to user",
-                "operationId": "subscribe to user",
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.RecipeDiff"
+                        }
                     },
                     "400": {
                         "description": "Bad Request"
                     },
-                    "401": {
-                        "description": "Unauthorized"
+                    "404": {
+                        "description": "Not Found"
                     },
                     "500": {
                         "description": "Internal Server Error"
@@ -892,17 +1036,204 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/{login}/unsubscribe": {
+        "/recipe/{id}/unlike": {
             "post": {
-                "description": "Unsubscribe from user",
+                "description": "Unlike recipe",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "subscription"
+                    "likes"
                 ],
-                "summary": "Unsubscribe from user",
-                "operationId": "unsubscribe from user",
+                "summary": "Unlike",
+                "operationId": "unlike",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/tags": {
+            "get": {
+                "description": "Get all tags with counts of their recipes",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Get all tags",
+                "operationId": "get all tags",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entities.TagWithCount"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/tags/{slug}/recipes": {
+            "get": {
+                "description": "Get the newest recipes with tag",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Get recipes with tag",
+                "operationId": "get tag recipes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "metric",
+                            "imperial"
+                        ],
+                        "type": "string",
+                        "name": "units",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.RecipeList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/user/{login}": {
+            "get": {
+                "description": "Get user info",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get user info",
+                "operationId": "get user info",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.JSONUserInfo"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "put": {
+                "description": "Update user",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Update user",
+                "operationId": "update user",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Icon",
+                        "name": "icon",
+                        "in": "formData"
+                    },
+                    {
+                        "maxLength": 1500,
+                        "type": "string",
+                        "name": "about",
+                        "in": "formData"
+                    },
+                    {
+                        "maxLength": 20,
+                        "minLength": 3,
+                        "type": "string",
+                        "name": "login",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "login",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "description": "Move the user with all the recipes and comments to the trash.\nThe account may be restored with POST /auth/restore until the trash is purged.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Delete user",
+                "operationId": "delete user",
                 "responses": {
                     "200": {
                         "description": "OK"
@@ -913,197 +1244,2892 @@ const docTemplate = `{
                     "401": {
                         "description": "Unauthorized"
                     },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/user/{login}/bookmarks": {
+            "get": {
+                "description": "Get recipes saved for later, the last saved first. Only the user may see them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bookmarks"
+                ],
+                "summary": "Get bookmarks",
+                "operationId": "get bookmarks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "metric",
+                            "imperial"
+                        ],
+                        "type": "string",
+                        "name": "units",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.RecipeList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/user/{login}/collections": {
+            "get": {
+                "description": "Get collections of the user. Private collections are shown to their owner only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "Get collections",
+                "operationId": "get collections",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entities.Collection"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
+            },
+            "post": {
+                "description": "Create a named collection of recipes",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "Create collection",
+                "operationId": "create collection",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Cover image",
+                        "name": "cover",
+                        "in": "formData"
+                    },
+                    {
+                        "maxLength": 1000,
+                        "type": "string",
+                        "name": "about",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "name": "is_public",
+                        "in": "formData"
+                    },
+                    {
+                        "maxLength": 100,
+                        "minLength": 1,
+                        "type": "string",
+                        "name": "title",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/user/{login}/collections/{id}": {
+            "get": {
+                "description": "Get the collection with its recipes in order",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "Get collection",
+                "operationId": "get collection",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.FullCollection"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "put": {
+                "description": "Update the collection",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "Update collection",
+                "operationId": "update collection",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Cover image",
+                        "name": "cover",
+                        "in": "formData"
+                    },
+                    {
+                        "maxLength": 1000,
+                        "type": "string",
+                        "name": "about",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "name": "is_public",
+                        "in": "formData"
+                    },
+                    {
+                        "maxLength": 100,
+                        "minLength": 1,
+                        "type": "string",
+                        "name": "title",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete the collection, its recipes are kept",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "Delete collection",
+                "operationId": "delete collection",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/user/{login}/collections/{id}/recipes": {
+            "put": {
+                "description": "Put the recipes of the collection in the new order. recipe_ids must list every recipe shown in the collection, recipes hidden from the owner go after them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "Reorder collection",
+                "operationId": "reorder collection",
+                "parameters": [
+                    {
+                        "description": "order",
+                        "name": "order",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.CollectionOrder"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "post": {
+                "description": "Add the recipe to the collection at the position or to its end",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "Add recipe to collection",
+                "operationId": "add recipe to collection",
+                "parameters": [
+                    {
+                        "description": "recipe",
+                        "name": "recipe",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.CollectionRecipe"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/user/{login}/collections/{id}/recipes/{recipe_id}": {
+            "delete": {
+                "description": "Remove the recipe from the collection",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "Remove recipe from collection",
+                "operationId": "remove recipe from collection",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/user/{login}/cooked/{id}": {
+            "delete": {
+                "description": "Remove the cook log and its photo from the cooking history of the user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cooked"
+                ],
+                "summary": "Delete cook log",
+                "operationId": "delete cook log",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/user/{login}/icon": {
+            "get": {
+                "description": "Get user icon",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get icon",
+                "operationId": "get icon",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.UserIcon"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/user/{login}/mealplan": {
+            "get": {
+                "description": "Get the meal plan for the days from \"from\" to \"to\" inclusive, at most 62 days",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mealplan"
+                ],
+                "summary": "Get meal plan",
+                "operationId": "get meal plan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "2025-01-06",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "2025-01-12",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.MealPlan"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "post": {
+                "description": "Put the recipe into the breakfast, lunch or dinner slot on the date",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mealplan"
+                ],
+                "summary": "Plan meal",
+                "operationId": "plan meal",
+                "parameters": [
+                    {
+                        "description": "entry",
+                        "name": "entry",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.AddMealPlanEntry"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/user/{login}/mealplan/copy": {
+            "post": {
+                "description": "Copy the seven days starting at \"from\" to the seven days starting at \"to\".\nMeals already planned in the target week are kept.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mealplan"
+                ],
+                "summary": "Copy meal plan week",
+                "operationId": "copy meal plan week",
+                "parameters": [
+                    {
+                        "description": "weeks",
+                        "name": "copy",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.MealPlanCopy"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/user/{login}/mealplan/{id}": {
+            "put": {
+                "description": "Move the planned meal to another slot or date or change its servings",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mealplan"
+                ],
+                "summary": "Update planned meal",
+                "operationId": "update planned meal",
+                "parameters": [
+                    {
+                        "description": "entry",
+                        "name": "entry",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.UpdateMealPlanEntry"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove the meal from the plan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mealplan"
+                ],
+                "summary": "Delete planned meal",
+                "operationId": "delete planned meal",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/user/{login}/pantry": {
+            "get": {
+                "description": "Get the ingredients the user has at home",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pantry"
+                ],
+                "summary": "Get pantry",
+                "operationId": "get pantry",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entities.PantryItem"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "post": {
+                "description": "Put the ingredients into the pantry, the ones which are there already are skipped",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pantry"
+                ],
+                "summary": "Add to pantry",
+                "operationId": "add to pantry",
+                "parameters": [
+                    {
+                        "description": "items",
+                        "name": "items",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.AddPantryItems"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/user/{login}/pantry/{id}": {
+            "delete": {
+                "description": "Remove the ingredient from the pantry",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pantry"
+                ],
+                "summary": "Delete from pantry",
+                "operationId": "delete from pantry",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/user/{login}/password": {
+            "put": {
+                "description": "Update user password",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Update user password",
+                "operationId": "update user password",
+                "parameters": [
+                    {
+                        "description": "User params",
+                        "name": "user",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/entities.UserPasswordUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/user/{login}/recipe": {
+            "post": {
+                "description": "Create recipe",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipe"
+                ],
+                "summary": "Create recipe",
+                "operationId": "create recipe",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Photos",
+                        "name": "photos",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Photo of a step, send step_photo_N for the N-th step",
+                        "name": "step_photo_1",
+                        "in": "formData"
+                    },
+                    {
+                        "maxLength": 10000,
+                        "type": "string",
+                        "name": "about",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "gluten"
+                        ],
+                        "name": "allergens",
+                        "in": "formData"
+                    },
+                    {
+                        "maximum": 3,
+                        "minimum": 1,
+                        "enum": [
+                            1,
+                            2,
+                            3
+                        ],
+                        "type": "integer",
+                        "name": "complexity",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "maxLength": 50,
+                        "type": "string",
+                        "example": "1h 15m",
+                        "name": "cook_time",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vegetarian"
+                        ],
+                        "name": "diets",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "example": "[{\"name\":\"flour\",\"quantity\":200,\"unit\":\"g\"}]",
+                        "name": "ingredients",
+                        "in": "formData"
+                    },
+                    {
+                        "maxLength": 10000,
+                        "type": "string",
+                        "name": "ingridients",
+                        "in": "formData"
+                    },
+                    {
+                        "maxLength": 10000,
+                        "type": "string",
+                        "name": "instructions",
+                        "in": "formData"
+                    },
+                    {
+                        "maxLength": 50,
+                        "type": "string",
+                        "example": "1h 30m",
+                        "name": "need_time",
+                        "in": "formData"
+                    },
+                    {
+                        "maxLength": 50,
+                        "type": "string",
+                        "example": "PT15M",
+                        "name": "prep_time",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2025-01-01T10:00:00Z",
+                        "name": "publish_at",
+                        "in": "formData"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "name": "servings",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "draft",
+                            "published",
+                            "scheduled"
+                        ],
+                        "type": "string",
+                        "default": "published",
+                        "description": "Status is published unless the author saves a draft or schedules the recipe.",
+                        "name": "status",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "example": "[{\"text\":\"Mix the flour with eggs\",\"duration\":15}]",
+                        "name": "steps",
+                        "in": "formData"
+                    },
+                    {
+                        "maxItems": 10,
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "breakfast"
+                        ],
+                        "name": "tags",
+                        "in": "formData"
+                    },
+                    {
+                        "maxLength": 50,
+                        "minLength": 3,
+                        "type": "string",
+                        "name": "title",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/user/{login}/recipe/{id}": {
+            "put": {
+                "description": "Update recipe",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipe"
+                ],
+                "summary": "Update recipe",
+                "operationId": "update recipe",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Photos",
+                        "name": "photos",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Photo of a step, send step_photo_N for the N-th step",
+                        "name": "step_photo_1",
+                        "in": "formData"
+                    },
+                    {
+                        "maxLength": 10000,
+                        "type": "string",
+                        "name": "about",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "gluten"
+                        ],
+                        "name": "allergens",
+                        "in": "formData"
+                    },
+                    {
+                        "maximum": 3,
+                        "minimum": 1,
+                        "enum": [
+                            1,
+                            2,
+                            3
+                        ],
+                        "type": "integer",
+                        "name": "complexity",
+                        "in": "formData"
+                    },
+                    {
+                        "maxLength": 50,
+                        "type": "string",
+                        "example": "1h 15m",
+                        "name": "cook_time",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "vegetarian"
+                        ],
+                        "name": "diets",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "example": "[{\"name\":\"flour\",\"quantity\":200,\"unit\":\"g\"}]",
+                        "name": "ingredients",
+                        "in": "formData"
+                    },
+                    {
+                        "maxLength": 10000,
+                        "type": "string",
+                        "name": "ingridients",
+                        "in": "formData"
+                    },
+                    {
+                        "maxLength": 10000,
+                        "type": "string",
+                        "name": "instructions",
+                        "in": "formData"
+                    },
+                    {
+                        "maxLength": 50,
+                        "type": "string",
+                        "example": "1h 30m",
+                        "name": "need_time",
+                        "in": "formData"
+                    },
+                    {
+                        "maxLength": 50,
+                        "type": "string",
+                        "example": "PT15M",
+                        "name": "prep_time",
+                        "in": "formData"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "servings",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "example": "[{\"text\":\"Mix the flour with eggs\",\"duration\":15}]",
+                        "name": "steps",
+                        "in": "formData"
+                    },
+                    {
+                        "maxItems": 10,
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "breakfast"
+                        ],
+                        "name": "tags",
+                        "in": "formData"
+                    },
+                    {
+                        "maxLength": 50,
+                        "minLength": 3,
+                        "type": "string",
+                        "name": "title",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete recipe",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipe"
+                ],
+                "summary": "Delete recipe",
+                "operationId": "delete recipe",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/user/{login}/recipe/{id}/revisions/{version}/restore": {
+            "post": {
+                "description": "Bring the recipe back to the revision. Photos of the recipe are kept as they are.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "revisions"
+                ],
+                "summary": "Restore recipe revision",
+                "operationId": "restore recipe revision",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/user/{login}/recipe/{id}/status": {
+            "put": {
+                "description": "Publish, schedule or archive the recipe or return it to drafts.\nSubscribers are notified when the recipe is published for the first time.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipe"
+                ],
+                "summary": "Change recipe status",
+                "operationId": "change recipe status",
+                "parameters": [
+                    {
+                        "description": "status",
+                        "name": "status",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.RecipeStatusChange"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/user/{login}/shoppinglist": {
+            "get": {
+                "description": "Get the shopping list, items to buy go first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shoppinglist"
+                ],
+                "summary": "Get shopping list",
+                "operationId": "get shopping list",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ShoppingList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove all the items from the shopping list or only the checked ones",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shoppinglist"
+                ],
+                "summary": "Clear shopping list",
+                "operationId": "clear shopping list",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Checked clears only the items which are bought already.",
+                        "name": "checked",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/user/{login}/shoppinglist/export": {
+            "get": {
+                "description": "Export the shopping list as plain text or as a Markdown task list",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "shoppinglist"
+                ],
+                "summary": "Export shopping list",
+                "operationId": "export shopping list",
+                "parameters": [
+                    {
+                        "enum": [
+                            "text",
+                            "markdown"
+                        ],
+                        "type": "string",
+                        "default": "text",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/user/{login}/shoppinglist/items": {
+            "post": {
+                "description": "Put an item which isn't from a recipe on the shopping list",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shoppinglist"
+                ],
+                "summary": "Add item to shopping list",
+                "operationId": "add item to shopping list",
+                "parameters": [
+                    {
+                        "description": "item",
+                        "name": "item",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.AddShoppingListItem"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ShoppingList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/user/{login}/shoppinglist/items/{id}": {
+            "put": {
+                "description": "Check the item off or change it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shoppinglist"
+                ],
+                "summary": "Update shopping list item",
+                "operationId": "update shopping list item",
+                "parameters": [
+                    {
+                        "description": "item",
+                        "name": "item",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.UpdateShoppingListItem"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove the item from the shopping list",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shoppinglist"
+                ],
+                "summary": "Delete shopping list item",
+                "operationId": "delete shopping list item",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/user/{login}/shoppinglist/mealplan": {
+            "post": {
+                "description": "Put the ingredients of the meals planned from \"from\" to \"to\" inclusive on the\nshopping list, scaled to the planned servings",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shoppinglist"
+                ],
+                "summary": "Add meal plan to shopping list",
+                "operationId": "add meal plan to shopping list",
+                "parameters": [
+                    {
+                        "description": "range",
+                        "name": "range",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.MealPlanRange"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ShoppingList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/user/{login}/shoppinglist/recipes": {
+            "post": {
+                "description": "Put the ingredients of the recipes on the shopping list. Identical ingredients\nin compatible units are summed up with the items which aren't bought yet.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shoppinglist"
+                ],
+                "summary": "Add recipes to shopping list",
+                "operationId": "add recipes to shopping list",
+                "parameters": [
+                    {
+                        "description": "recipes",
+                        "name": "recipes",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.AddShoppingListRecipes"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ShoppingList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/user/{login}/subscribe": {
+            "post": {
+                "description": "Subscribe to user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscription"
+                ],
+                "summary": "Subscribe to user",
+                "operationId": "subscribe to user",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/user/{login}/trash": {
+            "get": {
+                "description": "Get deleted recipes and comments of the user which may still be restored",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "Get trash",
+                "operationId": "get trash",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.Trash"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/user/{login}/trash/comments/{id}/restore": {
+            "post": {
+                "description": "Take the comment out of the trash",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "Restore comment",
+                "operationId": "restore comment",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/user/{login}/trash/recipes/{id}/restore": {
+            "post": {
+                "description": "Take the recipe out of the trash",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "Restore recipe",
+                "operationId": "restore recipe",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/user/{login}/unsubscribe": {
+            "post": {
+                "description": "Unsubscribe from user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscription"
+                ],
+                "summary": "Unsubscribe from user",
+                "operationId": "unsubscribe from user",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "entities.AddMealPlanEntry": {
+            "type": "object",
+            "required": [
+                "date",
+                "recipe_id",
+                "slot"
+            ],
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2025-01-06"
+                },
+                "recipe_id": {
+                    "type": "integer"
+                },
+                "servings": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1
+                },
+                "slot": {
+                    "type": "string",
+                    "enum": [
+                        "breakfast",
+                        "lunch",
+                        "dinner"
+                    ]
+                }
+            }
+        },
+        "entities.AddPantryItems": {
+            "type": "object",
+            "required": [
+                "names"
+            ],
+            "properties": {
+                "names": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "eggs",
+                        "flour"
+                    ]
+                }
+            }
+        },
+        "entities.AddShoppingListItem": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1,
+                    "example": "napkins"
+                },
+                "quantity": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 2
+                },
+                "unit": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "example": "pcs"
+                }
+            }
+        },
+        "entities.AddShoppingListRecipes": {
+            "type": "object",
+            "required": [
+                "recipes"
+            ],
+            "properties": {
+                "recipes": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/entities.ShoppingListRecipe"
+                    }
+                }
+            }
+        },
+        "entities.AuthUser": {
+            "type": "object",
+            "properties": {
+                "login": {
+                    "type": "string"
+                },
+                "session_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.Author": {
+            "type": "object",
+            "properties": {
+                "icon_url": {
+                    "type": "string"
+                },
+                "login": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.Collection": {
+            "type": "object",
+            "properties": {
+                "about": {
+                    "type": "string"
+                },
+                "cover_url": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_public": {
+                    "type": "boolean"
+                },
+                "recipes_count": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.CollectionOrder": {
+            "type": "object",
+            "required": [
+                "recipe_ids"
+            ],
+            "properties": {
+                "recipe_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "entities.CollectionRecipe": {
+            "type": "object",
+            "required": [
+                "recipe_id"
+            ],
+            "properties": {
+                "position": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "recipe_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.Comment": {
+            "type": "object",
+            "required": [
+                "text"
+            ],
+            "properties": {
+                "author": {
+                    "$ref": "#/definitions/entities.Author"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "text": {
+                    "type": "string",
+                    "maxLength": 250,
+                    "minLength": 1
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.CommentCreate": {
+            "type": "object",
+            "required": [
+                "text"
+            ],
+            "properties": {
+                "text": {
+                    "type": "string",
+                    "maxLength": 250,
+                    "minLength": 1
+                }
+            }
+        },
+        "entities.CommentDelete": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.CommentUpdate": {
+            "type": "object",
+            "required": [
+                "id",
+                "text"
+            ],
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "text": {
+                    "type": "string",
+                    "maxLength": 250,
+                    "minLength": 1
+                }
+            }
+        },
+        "entities.CookLog": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "description": "Author is set when the logs of a recipe are listed, Recipe when the\nlogs of a user are.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entities.Author"
+                        }
+                    ]
+                },
+                "cooked_on": {
+                    "type": "string",
+                    "example": "2025-01-06"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Added more garlic"
+                },
+                "photo_url": {
+                    "type": "string"
+                },
+                "recipe": {
+                    "$ref": "#/definitions/entities.RecipeWithAuthor"
+                },
+                "recipe_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.FullCollection": {
+            "type": "object",
+            "properties": {
+                "collection": {
+                    "$ref": "#/definitions/entities.Collection"
+                },
+                "recipes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.RecipeWithAuthor"
+                    }
+                }
+            }
+        },
+        "entities.FullRecipe": {
+            "type": "object",
+            "properties": {
+                "comments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.Comment"
+                    }
+                },
+                "cook_photos": {
+                    "description": "CookPhotos are the latest photos users took of the cooked recipe.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.CookLog"
+                    }
+                },
+                "cooked_count": {
+                    "type": "integer"
+                },
+                "forks_count": {
+                    "type": "integer"
+                },
+                "is_bookmarked": {
+                    "type": "boolean"
+                },
+                "is_liked": {
+                    "type": "boolean"
+                },
+                "likes_count": {
+                    "type": "integer"
+                },
+                "lineage": {
+                    "description": "Lineage lists the recipes this one was forked from, the closest first.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.RecipeAncestor"
+                    }
+                },
+                "nutrition": {
+                    "$ref": "#/definitions/entities.Nutrition"
+                },
+                "rating": {
+                    "$ref": "#/definitions/entities.RatingSummary"
+                },
+                "recipe": {
+                    "$ref": "#/definitions/entities.RecipeWithAuthor"
+                },
+                "user_rating": {
+                    "description": "UserRating is the rating the viewer gave to the recipe, zero if none.",
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.GetRecipeAuthor": {
+            "type": "object",
+            "properties": {
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.Ingredient": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "display_quantity": {
+                    "description": "DisplayQuantity is a human friendly quantity (e.g. \"1 1/2\") set when\nthe ingredient was recalculated for the reader. It is never saved.",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1,
+                    "example": "flour"
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 250,
+                    "example": "sifted"
+                },
+                "quantity": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 200
+                },
+                "unit": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "example": "g"
+                }
+            }
+        },
+        "entities.IngredientSearch": {
+            "type": "object",
+            "properties": {
+                "ignore_pantry": {
+                    "description": "IgnorePantry searches by Ingredients only.",
+                    "type": "boolean"
+                },
+                "ingredients": {
+                    "description": "Ingredients are searched together with the pantry of the signed in user.",
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "eggs",
+                        "milk"
+                    ]
+                },
+                "limit": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1,
+                    "example": 20
+                },
+                "max_missing": {
+                    "description": "MaxMissing drops recipes which need more ingredients than that to be bought.",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 2
+                },
+                "offset": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 0
+                },
+                "units": {
+                    "description": "Units converts the ingredients and temperatures of the found recipes.",
+                    "type": "string",
+                    "enum": [
+                        "metric",
+                        "imperial"
+                    ]
+                }
+            }
+        },
+        "entities.JSONUserInfo": {
+            "type": "object",
+            "properties": {
+                "user": {
+                    "$ref": "#/definitions/entities.UserInfo"
+                }
+            }
+        },
+        "entities.JWTData": {
+            "type": "object",
+            "properties": {
+                "user_id": {}
+            }
+        },
+        "entities.JWTToken": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.Labels": {
+            "type": "object",
+            "properties": {
+                "allergens": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "diets": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "entities.LabelsSuggestion": {
+            "type": "object",
+            "required": [
+                "ingredients"
+            ],
+            "properties": {
+                "ingredients": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/entities.Ingredient"
+                    }
+                }
+            }
+        },
+        "entities.MealPlan": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.MealPlanDay"
+                    }
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2025-01-06"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2025-01-12"
+                }
+            }
+        },
+        "entities.MealPlanCopy": {
+            "type": "object",
+            "required": [
+                "from",
+                "to"
+            ],
+            "properties": {
+                "from": {
+                    "type": "string",
+                    "example": "2025-01-06"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2025-01-13"
+                }
+            }
+        },
+        "entities.MealPlanDay": {
+            "type": "object",
+            "properties": {
+                "breakfast": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.MealPlanEntry"
+                    }
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2025-01-06"
+                },
+                "dinner": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.MealPlanEntry"
+                    }
+                },
+                "lunch": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.MealPlanEntry"
+                    }
+                }
+            }
+        },
+        "entities.MealPlanEntry": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2025-01-06"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "recipe": {
+                    "$ref": "#/definitions/entities.RecipeWithAuthor"
+                },
+                "servings": {
+                    "type": "integer"
+                },
+                "slot": {
+                    "type": "string",
+                    "enum": [
+                        "breakfast",
+                        "lunch",
+                        "dinner"
+                    ]
+                }
+            }
+        },
+        "entities.MealPlanRange": {
+            "type": "object",
+            "required": [
+                "from",
+                "to"
+            ],
+            "properties": {
+                "from": {
+                    "type": "string",
+                    "example": "2025-01-06"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2025-01-12"
+                }
+            }
+        },
+        "entities.Nutrition": {
+            "type": "object",
+            "properties": {
+                "ingredients": {
+                    "type": "integer"
+                },
+                "matched_ingredients": {
+                    "description": "MatchedIngredients is the count of ingredients which were found in the\nnutrition reference, the rest aren't counted.",
+                    "type": "integer"
+                },
+                "per_serving": {
+                    "$ref": "#/definitions/entities.NutritionFacts"
+                },
+                "total": {
+                    "$ref": "#/definitions/entities.NutritionFacts"
+                }
+            }
+        },
+        "entities.NutritionFacts": {
+            "type": "object",
+            "properties": {
+                "calories": {
+                    "type": "number"
+                },
+                "carbs": {
+                    "type": "number"
+                },
+                "fat": {
+                    "type": "number"
+                },
+                "protein": {
+                    "type": "number"
+                }
+            }
+        },
+        "entities.PantryItem": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "eggs"
+                }
+            }
+        },
+        "entities.RateRecipe": {
+            "type": "object",
+            "required": [
+                "rating"
+            ],
+            "properties": {
+                "rating": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1,
+                    "example": 5
+                },
+                "review": {
+                    "type": "string",
+                    "maxLength": 2000,
+                    "example": "Cooked it twice already"
+                }
+            }
+        },
+        "entities.Rating": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "$ref": "#/definitions/entities.Author"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "rating": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "review": {
+                    "type": "string",
+                    "example": "Cooked it twice already"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
             }
-        }
-    },
-    "definitions": {
-        "entities.AuthUser": {
+        },
+        "entities.RatingList": {
             "type": "object",
             "properties": {
-                "login": {
+                "next_cursor": {
                     "type": "string"
                 },
-                "session_id": {
-                    "type": "string"
+                "ratings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.Rating"
+                    }
                 }
             }
         },
-        "entities.Author": {
+        "entities.RatingSummary": {
             "type": "object",
             "properties": {
-                "icon_url": {
-                    "type": "string"
+                "average": {
+                    "type": "number",
+                    "example": 4.5
                 },
-                "login": {
-                    "type": "string"
+                "count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "distribution": {
+                    "description": "Distribution holds the counts of ratings from one star to five stars.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        0,
+                        0,
+                        1,
+                        4,
+                        7
+                    ]
                 }
             }
         },
-        "entities.Comment": {
+        "entities.Recipe": {
             "type": "object",
             "required": [
-                "text"
+                "about",
+                "complexity",
+                "ingridients",
+                "instructions",
+                "title"
             ],
             "properties": {
-                "author": {
-                    "$ref": "#/definitions/entities.Author"
+                "about": {
+                    "type": "string",
+                    "maxLength": 10000
+                },
+                "allergens": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "complexity": {
+                    "type": "integer",
+                    "maximum": 3,
+                    "minimum": 1,
+                    "enum": [
+                        1,
+                        2,
+                        3
+                    ]
+                },
+                "cook_minutes": {
+                    "type": "integer"
                 },
                 "created_at": {
                     "type": "string"
                 },
+                "creator_user_id": {
+                    "type": "integer"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "diets": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "forked_from": {
+                    "type": "integer"
+                },
                 "id": {
                     "type": "integer"
                 },
-                "text": {
+                "ingredients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.Ingredient"
+                    }
+                },
+                "ingridients": {
                     "type": "string",
-                    "maxLength": 250,
-                    "minLength": 1
+                    "maxLength": 10000
+                },
+                "instructions": {
+                    "type": "string",
+                    "maxLength": 10000
+                },
+                "need_time": {
+                    "type": "string",
+                    "example": "1h 30m"
+                },
+                "photos_urls": {
+                    "type": "string"
+                },
+                "prep_minutes": {
+                    "type": "integer"
+                },
+                "publish_at": {
+                    "type": "string"
+                },
+                "published_at": {
+                    "type": "string"
+                },
+                "servings": {
+                    "type": "integer"
+                },
+                "snippet": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "published",
+                        "archived",
+                        "scheduled"
+                    ]
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.RecipeStep"
+                    }
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.Tag"
+                    }
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "minLength": 3
+                },
+                "total_minutes": {
+                    "type": "integer"
                 },
                 "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "entities.CommentCreate": {
+        "entities.RecipeAncestor": {
             "type": "object",
-            "required": [
-                "text"
-            ],
             "properties": {
-                "text": {
-                    "type": "string",
-                    "maxLength": 250,
-                    "minLength": 1
+                "author_login": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
                 }
             }
         },
-        "entities.CommentDelete": {
+        "entities.RecipeDiff": {
             "type": "object",
-            "required": [
-                "id"
-            ],
             "properties": {
-                "id": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.RecipeFieldChange"
+                    }
+                },
+                "from": {
+                    "type": "integer"
+                },
+                "to": {
                     "type": "integer"
                 }
             }
         },
-        "entities.CommentUpdate": {
+        "entities.RecipeFieldChange": {
             "type": "object",
-            "required": [
-                "id",
-                "text"
-            ],
             "properties": {
-                "id": {
-                    "type": "integer"
+                "field": {
+                    "type": "string",
+                    "example": "title"
                 },
-                "text": {
+                "from": {},
+                "to": {}
+            }
+        },
+        "entities.RecipeFilter": {
+            "type": "object",
+            "properties": {
+                "author": {
                     "type": "string",
-                    "maxLength": 250,
-                    "minLength": 1
+                    "maxLength": 20,
+                    "example": "chef"
+                },
+                "complexity_max": {
+                    "type": "integer",
+                    "maximum": 3,
+                    "minimum": 1,
+                    "enum": [
+                        1,
+                        2,
+                        3
+                    ]
+                },
+                "complexity_min": {
+                    "type": "integer",
+                    "maximum": 3,
+                    "minimum": 1,
+                    "enum": [
+                        1,
+                        2,
+                        3
+                    ]
+                },
+                "created_after": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "created_before": {
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                },
+                "cursor": {
+                    "type": "string"
+                },
+                "diet": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "vegan"
+                    ]
+                },
+                "exclude_allergens": {
+                    "description": "ExcludeAllergens drops recipes labeled with any of the allergens,\nDiet keeps recipes labeled with all of the diets.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "nuts"
+                    ]
+                },
+                "exclude_ingredients": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "nuts"
+                    ]
+                },
+                "include_ingredients": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "flour",
+                        "eggs"
+                    ]
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 25
+                },
+                "max_cooking_time": {
+                    "description": "MaxCookingTime is measured in minutes.",
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 60
+                },
+                "min_likes": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 10
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "order_by": {
+                    "type": "integer",
+                    "maximum": 1,
+                    "minimum": -1,
+                    "enum": [
+                        -1,
+                        0,
+                        1
+                    ]
+                },
+                "order_field": {
+                    "type": "string",
+                    "enum": [
+                        "title",
+                        "complexitiy",
+                        "updated_at",
+                        "need_time",
+                        "relevance",
+                        "rating",
+                        "emtpy"
+                    ],
+                    "example": "title"
+                },
+                "query": {
+                    "type": "string",
+                    "example": "tasty food"
+                },
+                "status": {
+                    "description": "Status other than published lists recipes of the viewer only.",
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "published",
+                        "archived",
+                        "scheduled"
+                    ],
+                    "example": "draft"
+                },
+                "tags": {
+                    "description": "Tags are slugs, a recipe must have all of them.",
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "breakfast"
+                    ]
+                },
+                "units": {
+                    "description": "Units converts the ingredients and temperatures of the found recipes.",
+                    "type": "string",
+                    "enum": [
+                        "metric",
+                        "imperial"
+                    ]
+                }
+            }
+        },
+        "entities.RecipeInfo": {
+            "type": "object",
+            "properties": {
+                "info": {
+                    "$ref": "#/definitions/entities.FullRecipe"
                 }
             }
         },
-        "entities.FullRecipe": {
+        "entities.RecipeList": {
             "type": "object",
             "properties": {
-                "comments": {
+                "next_cursor": {
+                    "type": "string"
+                },
+                "recipes": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/entities.Comment"
+                        "$ref": "#/definitions/entities.RecipeWithAuthor"
                     }
-                },
-                "is_liked": {
-                    "type": "boolean"
-                },
-                "likes_count": {
-                    "type": "integer"
-                },
-                "recipe": {
-                    "$ref": "#/definitions/entities.RecipeWithAuthor"
                 }
             }
         },
-        "entities.GetRecipeAuthor": {
+        "entities.RecipeMatch": {
             "type": "object",
             "properties": {
-                "user_id": {
+                "matched": {
+                    "description": "Matched is the count of the ingredients of the recipe which are at hand.",
+                    "type": "integer"
+                },
+                "missing": {
+                    "description": "Missing are the ingredients which have to be bought.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "recipe": {
+                    "$ref": "#/definitions/entities.RecipeWithAuthor"
+                },
+                "total": {
                     "type": "integer"
                 }
             }
         },
-        "entities.JSONUserInfo": {
+        "entities.RecipeMatchList": {
             "type": "object",
             "properties": {
-                "user": {
-                    "$ref": "#/definitions/entities.UserInfo"
+                "recipes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.RecipeMatch"
+                    }
                 }
             }
         },
-        "entities.JWTData": {
-            "type": "object",
-            "properties": {
-                "user_id": {}
-            }
-        },
-        "entities.JWTToken": {
+        "entities.RecipeRevision": {
             "type": "object",
-            "required": [
-                "token"
-            ],
             "properties": {
-                "token": {
+                "created_at": {
                     "type": "string"
+                },
+                "recipe_id": {
+                    "type": "integer"
+                },
+                "snapshot": {
+                    "$ref": "#/definitions/entities.Recipe"
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
-        "entities.RecipeFilter": {
+        "entities.RecipeStatusChange": {
             "type": "object",
+            "required": [
+                "status"
+            ],
             "properties": {
-                "limit": {
-                    "type": "integer",
-                    "example": 25
-                },
-                "offset": {
-                    "type": "integer",
-                    "example": 0
-                },
-                "order_by": {
-                    "type": "integer",
-                    "maximum": 1,
-                    "minimum": -1,
-                    "enum": [
-                        -1,
-                        0,
-                        1
-                    ]
+                "publish_at": {
+                    "type": "string",
+                    "example": "2025-01-01T10:00:00Z"
                 },
-                "order_field": {
+                "status": {
                     "type": "string",
                     "enum": [
-                        "title",
-                        "about",
-                        "ingridients",
-                        "emtpy"
+                        "draft",
+                        "published",
+                        "archived",
+                        "scheduled"
                     ],
-                    "example": "title"
-                },
-                "query": {
-                    "type": "string",
-                    "example": "tasty food"
+                    "example": "published"
                 }
             }
         },
-        "entities.RecipeInfo": {
+        "entities.RecipeStep": {
             "type": "object",
+            "required": [
+                "text"
+            ],
             "properties": {
-                "info": {
-                    "$ref": "#/definitions/entities.FullRecipe"
+                "duration": {
+                    "type": "integer",
+                    "maximum": 10080,
+                    "minimum": 0,
+                    "example": 15
+                },
+                "photo_url": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string",
+                    "maxLength": 2000,
+                    "minLength": 1,
+                    "example": "Mix the flour with eggs"
                 }
             }
         },
@@ -1114,13 +4140,18 @@ const docTemplate = `{
                 "complexity",
                 "ingridients",
                 "instructions",
-                "need_time",
                 "title"
             ],
             "properties": {
                 "about": {
                     "type": "string",
-                    "maxLength": 2500
+                    "maxLength": 10000
+                },
+                "allergens": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
                 "author": {
                     "$ref": "#/definitions/entities.Author"
@@ -1135,39 +4166,284 @@ const docTemplate = `{
                         3
                     ]
                 },
+                "cook_minutes": {
+                    "type": "integer"
+                },
                 "created_at": {
                     "type": "string"
                 },
                 "creator_user_id": {
                     "type": "integer"
                 },
+                "diets": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "forked_from": {
+                    "type": "integer"
+                },
                 "id": {
                     "type": "integer"
                 },
+                "ingredients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.Ingredient"
+                    }
+                },
                 "ingridients": {
                     "type": "string",
-                    "maxLength": 1500
+                    "maxLength": 10000
                 },
                 "instructions": {
                     "type": "string",
-                    "maxLength": 2000
+                    "maxLength": 10000
                 },
                 "need_time": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "1h 30m"
                 },
                 "photos_urls": {
                     "type": "string"
                 },
+                "prep_minutes": {
+                    "type": "integer"
+                },
+                "publish_at": {
+                    "type": "string"
+                },
+                "published_at": {
+                    "type": "string"
+                },
+                "servings": {
+                    "type": "integer"
+                },
+                "snippet": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "published",
+                        "archived",
+                        "scheduled"
+                    ]
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.RecipeStep"
+                    }
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.Tag"
+                    }
+                },
                 "title": {
                     "type": "string",
                     "maxLength": 50,
                     "minLength": 3
                 },
+                "total_minutes": {
+                    "type": "integer"
+                },
                 "updated_at": {
                     "type": "string"
                 }
             }
         },
+        "entities.ShoppingList": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.ShoppingListItem"
+                    }
+                }
+            }
+        },
+        "entities.ShoppingListItem": {
+            "type": "object",
+            "properties": {
+                "checked": {
+                    "type": "boolean"
+                },
+                "display_quantity": {
+                    "description": "DisplayQuantity is the quantity rounded the way it is measured in a kitchen.",
+                    "type": "string",
+                    "example": "0.5"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "flour"
+                },
+                "quantity": {
+                    "type": "number",
+                    "example": 0.5
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "kg"
+                }
+            }
+        },
+        "entities.ShoppingListRecipe": {
+            "type": "object",
+            "required": [
+                "recipe_id"
+            ],
+            "properties": {
+                "recipe_id": {
+                    "type": "integer"
+                },
+                "servings": {
+                    "description": "Servings defaults to the servings of the recipe.",
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1
+                }
+            }
+        },
+        "entities.Tag": {
+            "type": "object",
+            "properties": {
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "meal",
+                        "category",
+                        "cuisine"
+                    ],
+                    "example": "meal"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Breakfast"
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "breakfast"
+                }
+            }
+        },
+        "entities.TagWithCount": {
+            "type": "object",
+            "properties": {
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "meal",
+                        "category",
+                        "cuisine"
+                    ],
+                    "example": "meal"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Breakfast"
+                },
+                "recipes_count": {
+                    "type": "integer"
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "breakfast"
+                }
+            }
+        },
+        "entities.Trash": {
+            "type": "object",
+            "properties": {
+                "comments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.TrashComment"
+                    }
+                },
+                "purge_after": {
+                    "description": "PurgeAfter is how long deleted items may be restored.",
+                    "type": "string",
+                    "example": "720h0m0s"
+                },
+                "recipes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.Recipe"
+                    }
+                }
+            }
+        },
+        "entities.TrashComment": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "recipe_id": {
+                    "type": "integer"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.UpdateMealPlanEntry": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2025-01-07"
+                },
+                "servings": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1
+                },
+                "slot": {
+                    "type": "string",
+                    "enum": [
+                        "breakfast",
+                        "lunch",
+                        "dinner"
+                    ]
+                }
+            }
+        },
+        "entities.UpdateShoppingListItem": {
+            "type": "object",
+            "properties": {
+                "checked": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "quantity": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "unit": {
+                    "type": "string",
+                    "maxLength": 20
+                }
+            }
+        },
         "entities.UserIcon": {
             "type": "object",
             "properties": {
@@ -1182,6 +4458,19 @@ const docTemplate = `{
                 "about": {
                     "type": "string"
                 },
+                "collections": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.Collection"
+                    }
+                },
+                "cook_history": {
+                    "description": "CookHistory is shown to the user only.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.CookLog"
+                    }
+                },
                 "created_at": {
                     "type": "string"
                 },
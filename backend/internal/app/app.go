@@ -2,6 +2,7 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"os"
@@ -74,12 +75,38 @@ func Run(cfg *config.Config) {
 	userUseCase := usecases.NewUserUsecase(repo.NewUserRepository(pg), sessionUseCase, cfg.DEFAULT_ICON_URL, s3, jwtUseCase, redisRepo, likeUseCase)
 	commentUseCase := usecases.NewCommentUseCase(repo.NewCommentRepository(pg), userUseCase)
 	subscribeUseCase := usecases.NewSubscribeUsecase(repo.NewSubscribeRepository(pg), rmqRepo, userUseCase)
+	nutritionUseCase := usecases.NewNutritionUseCase(repo.NewNutritionRepository(pg), redisRepo)
+	scaleUseCase := usecases.NewScaleUseCase()
+	bookmarkUseCase := usecases.NewBookmarkUseCase(repo.NewBookmarkRepository(pg), userUseCase, scaleUseCase)
+	ratingUseCase := usecases.NewRatingUseCase(repo.NewRatingRepository(pg), repo.NewRecipeRepository(pg))
+	cookLogUseCase := usecases.NewCookLogUseCase(repo.NewCookLogRepository(pg), repo.NewRecipeRepository(pg), userUseCase, s3)
 	recipeUseCase := usecases.NewRecipeUsecase(repo.NewRecipeRepository(pg), userUseCase, likeUseCase,
-		s3, commentUseCase, subscribeUseCase, redisRepo)
+		s3, commentUseCase, subscribeUseCase, redisRepo, scaleUseCase, nutritionUseCase, bookmarkUseCase, ratingUseCase,
+		cookLogUseCase)
+	tagUseCase := usecases.NewTagUseCase(repo.NewTagRepository(pg), recipeUseCase)
+	labelUseCase := usecases.NewLabelUseCase()
+	revisionUseCase := usecases.NewRevisionUseCase(repo.NewRecipeRepository(pg), recipeUseCase)
+	trashUseCase := usecases.NewTrashUseCase(repo.NewTrashRepository(pg), userUseCase, s3, redisRepo,
+		cfg.Trash.Retention, cfg.Trash.PurgeInterval)
+	collectionUseCase := usecases.NewCollectionUseCase(repo.NewCollectionRepository(pg), userUseCase, s3)
+	mealPlanUseCase := usecases.NewMealPlanUseCase(repo.NewMealPlanRepository(pg), userUseCase)
+	shoppingListUseCase := usecases.NewShoppingListUseCase(repo.NewShoppingListRepository(pg), userUseCase, recipeUseCase,
+		mealPlanUseCase, scaleUseCase)
+	pantryUseCase := usecases.NewPantryUseCase(repo.NewPantryRepository(pg), repo.NewRecipeRepository(pg), userUseCase,
+		scaleUseCase)
+
+	// Background jobs
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	defer stopScheduler()
+	scheduler := usecases.NewPublishScheduler(repo.NewRecipeRepository(pg), redisRepo, subscribeUseCase, cfg.Scheduler.Interval)
+	go scheduler.Run(schedulerCtx)
+	go trashUseCase.Run(schedulerCtx)
 
 	// HTTP Server
 	handler := gin.New()
-	v1.NewRouter(handler, sessionUseCase, userUseCase, likeUseCase, recipeUseCase, commentUseCase, subscribeUseCase)
+	v1.NewRouter(handler, sessionUseCase, userUseCase, likeUseCase, recipeUseCase, commentUseCase, subscribeUseCase, tagUseCase, labelUseCase,
+		revisionUseCase, trashUseCase, collectionUseCase, bookmarkUseCase, mealPlanUseCase, shoppingListUseCase,
+		pantryUseCase, ratingUseCase, cookLogUseCase)
 	httpServer := httpserver.New(handler, httpserver.Port(cfg.HTTP.Port))
 
 	// Waiting signal
@@ -28,6 +28,9 @@ func GetErrMessages(errs error) error {
 			if v.Tag() == "max" {
 				newErrMes += fmt.Sprintf("Maximum lenght for field %s is %v;", v.Field(), v.Param())
 			}
+			if v.Tag() == "allergen" || v.Tag() == "diet" {
+				newErrMes += fmt.Sprintf("Field %s has unknown %s %v;", v.Field(), v.Tag(), v.Value())
+			}
 		}
 	} else {
 		newErrMes = errs.Error()
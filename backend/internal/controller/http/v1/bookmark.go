@@ -0,0 +1,177 @@
+package v1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/Homyakadze14/RecipeSite/internal/common"
+	"github.com/Homyakadze14/RecipeSite/internal/entities"
+	"github.com/Homyakadze14/RecipeSite/internal/usecases"
+	"github.com/gin-gonic/gin"
+)
+
+type bookmarkRoutes struct {
+	u  *usecases.BookmarkUseCase
+	su *usecases.SessionUseCase
+}
+
+func NewBookmarkRoutes(handler *gin.RouterGroup, u *usecases.BookmarkUseCase, su *usecases.SessionUseCase) {
+	r := &bookmarkRoutes{u, su}
+
+	h := handler.Group("/recipe/:id")
+	{
+		h.Use(su.Auth())
+		h.POST("/bookmark", r.bookmark)
+		h.DELETE("/bookmark", r.unbookmark)
+	}
+
+	ur := handler.Group("/user/:login/bookmarks")
+	{
+		ur.Use(su.Auth())
+		ur.GET("", r.getRecipes)
+	}
+}
+
+// @Summary     Bookmark
+// @Description Save the recipe for later
+// @ID          bookmark
+// @Tags  	    bookmarks
+// @Produce     json
+// @Success     200
+// @Failure     400
+// @Failure     401
+// @Failure     404
+// @Failure     500
+// @Router      /recipe/{id}/bookmark [post]
+func (r *bookmarkRoutes) bookmark(c *gin.Context) {
+	recipeID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		slog.Error(common.ErrRecipeIDType.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.ErrRecipeIDType.Error()})
+		return
+	}
+
+	sess, err := r.su.SessionFromContext(c)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	bookmark := &entities.Bookmark{
+		UserID:   sess.UserID,
+		RecipeID: recipeID,
+	}
+
+	err = r.u.Bookmark(c.Request.Context(), bookmark)
+	if err != nil {
+		slog.Error(err.Error())
+		if errors.Is(err, usecases.ErrAlreadyBookmarked) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": usecases.ErrAlreadyBookmarked.Error()})
+			return
+		}
+		if errors.Is(err, usecases.ErrRecipeNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": usecases.ErrRecipeNotFound.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "recipe bookmarked"})
+}
+
+// @Summary     Remove bookmark
+// @Description Remove the recipe from saved for later
+// @ID          unbookmark
+// @Tags  	    bookmarks
+// @Produce     json
+// @Success     200
+// @Failure     400
+// @Failure     401
+// @Failure     500
+// @Router      /recipe/{id}/bookmark [delete]
+func (r *bookmarkRoutes) unbookmark(c *gin.Context) {
+	recipeID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		slog.Error(common.ErrRecipeIDType.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.ErrRecipeIDType.Error()})
+		return
+	}
+
+	sess, err := r.su.SessionFromContext(c)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	bookmark := &entities.Bookmark{
+		UserID:   sess.UserID,
+		RecipeID: recipeID,
+	}
+
+	err = r.u.Unbookmark(c.Request.Context(), bookmark)
+	if err != nil {
+		slog.Error(err.Error())
+		if errors.Is(err, usecases.ErrNotBookmarked) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": usecases.ErrNotBookmarked.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "bookmark removed"})
+}
+
+// @Summary     Get bookmarks
+// @Description Get recipes saved for later, the last saved first. Only the user may see them.
+// @ID          get bookmarks
+// @Tags  	    bookmarks
+// @Param 		page query entities.RecipePage false "page"
+// @Produce     json
+// @Success     200 {object} entities.RecipeList
+// @Failure     400
+// @Failure     401
+// @Failure     404
+// @Failure     500
+// @Router      /user/{login}/bookmarks [get]
+func (r *bookmarkRoutes) getRecipes(c *gin.Context) {
+	var page entities.RecipePage
+	if err := c.ShouldBindQuery(&page); err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.GetErrMessages(err).Error()})
+		return
+	}
+
+	sess, err := r.su.SessionFromContext(c)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	recipes, err := r.u.GetRecipes(c.Request.Context(), c.Param("login"), sess.UserID, &page)
+	if err != nil {
+		slog.Error(err.Error())
+		if errors.Is(err, usecases.ErrUserNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": usecases.ErrUserNotFound.Error()})
+			return
+		}
+		if errors.Is(err, common.ErrNoPermissions) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": common.ErrNoPermissions.Error()})
+			return
+		}
+		if errors.Is(err, usecases.ErrBadCursor) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": usecases.ErrBadCursor.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, recipes)
+}
@@ -0,0 +1,434 @@
+package v1
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Homyakadze14/RecipeSite/internal/common"
+	"github.com/Homyakadze14/RecipeSite/internal/entities"
+	"github.com/Homyakadze14/RecipeSite/internal/usecases"
+	"github.com/gin-gonic/gin"
+)
+
+var ErrCollectionIDType = errors.New("collection ID must be integer")
+
+type collectionRoutes struct {
+	u  *usecases.CollectionUseCase
+	su *usecases.SessionUseCase
+}
+
+func NewCollectionRoutes(handler *gin.RouterGroup, u *usecases.CollectionUseCase, su *usecases.SessionUseCase) {
+	r := &collectionRoutes{u, su}
+
+	h := handler.Group("/user/:login/collections")
+	{
+		h.GET("", r.getAll)
+		h.GET("/:id", r.get)
+	}
+
+	ur := handler.Group("/user/:login/collections")
+	{
+		ur.Use(su.Auth())
+		ur.POST("", r.create)
+		ur.PUT("/:id", r.update)
+		ur.DELETE("/:id", r.delete)
+		ur.POST("/:id/recipes", r.addRecipe)
+		ur.PUT("/:id/recipes", r.reorder)
+		ur.DELETE("/:id/recipes/:recipe_id", r.removeRecipe)
+	}
+}
+
+func (r *collectionRoutes) getCover(c *gin.Context) (io.ReadSeeker, error) {
+	err := c.Request.ParseMultipartForm(maxFilesSize)
+	if err != nil {
+		return nil, common.ErrHudgeFiles
+	}
+
+	fileHeader, err := c.FormFile("cover")
+
+	if fileHeader == nil {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.Contains(fileHeader.Header.Get("Content-Type"), "image") {
+		return nil, common.ErrImageType
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return file, nil
+}
+
+// collectionError writes the response for an error of the collection use case.
+func (r *collectionRoutes) collectionError(c *gin.Context, err error) {
+	slog.Error(err.Error())
+	switch {
+	case errors.Is(err, usecases.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": usecases.ErrUserNotFound.Error()})
+	case errors.Is(err, usecases.ErrCollectionNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": usecases.ErrCollectionNotFound.Error()})
+	case errors.Is(err, usecases.ErrRecipeNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": usecases.ErrRecipeNotFound.Error()})
+	case errors.Is(err, usecases.ErrRecipeNotInCollection):
+		c.JSON(http.StatusNotFound, gin.H{"error": usecases.ErrRecipeNotInCollection.Error()})
+	case errors.Is(err, common.ErrNoPermissions):
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.ErrNoPermissions.Error()})
+	case errors.Is(err, usecases.ErrRecipeInCollection):
+		c.JSON(http.StatusBadRequest, gin.H{"error": usecases.ErrRecipeInCollection.Error()})
+	case errors.Is(err, usecases.ErrCollectionOrder):
+		c.JSON(http.StatusBadRequest, gin.H{"error": usecases.ErrCollectionOrder.Error()})
+	case errors.Is(err, common.ErrHudgeFiles):
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.ErrHudgeFiles.Error()})
+	case errors.Is(err, common.ErrImageType):
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.ErrImageType.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+	}
+}
+
+// @Summary     Get collections
+// @Description Get collections of the user. Private collections are shown to their owner only.
+// @ID          get collections
+// @Tags  	    collections
+// @Produce     json
+// @Success     200 {object} []entities.Collection
+// @Failure     404
+// @Failure     500
+// @Router      /user/{login}/collections [get]
+func (r *collectionRoutes) getAll(c *gin.Context) {
+	userID, err := viewerID(r.su, c)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	collections, err := r.u.GetAll(c.Request.Context(), c.Param("login"), userID)
+	if err != nil {
+		r.collectionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"collections": collections})
+}
+
+// @Summary     Get collection
+// @Description Get the collection with its recipes in order
+// @ID          get collection
+// @Tags  	    collections
+// @Produce     json
+// @Success     200 {object} entities.FullCollection
+// @Failure     400
+// @Failure     404
+// @Failure     500
+// @Router      /user/{login}/collections/{id} [get]
+func (r *collectionRoutes) get(c *gin.Context) {
+	collectionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		slog.Error(ErrCollectionIDType.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": ErrCollectionIDType.Error()})
+		return
+	}
+
+	userID, err := viewerID(r.su, c)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	collection, err := r.u.Get(c.Request.Context(), c.Param("login"), userID, collectionID)
+	if err != nil {
+		r.collectionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, collection)
+}
+
+// @Summary     Create collection
+// @Description Create a named collection of recipes
+// @ID          create collection
+// @Tags  	    collections
+// @Param 		cover formData file false "Cover image"
+// @Param 		collection formData entities.CreateCollection true "Collection params"
+// @Accept      mpfd
+// @Produce     json
+// @Success     200
+// @Failure     400
+// @Failure     401
+// @Failure     404
+// @Failure     500
+// @Router      /user/{login}/collections [post]
+func (r *collectionRoutes) create(c *gin.Context) {
+	contentType := c.Request.Header.Get("Content-Type")
+	if !strings.Contains(contentType, "multipart/form-data") {
+		slog.Error(ErrContentType.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": ErrContentType.Error()})
+		return
+	}
+
+	var params entities.CreateCollection
+	if err := c.Bind(&params); err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.GetErrMessages(err).Error()})
+		return
+	}
+
+	var err error
+	params.Cover, err = r.getCover(c)
+	if err != nil {
+		r.collectionError(c, err)
+		return
+	}
+
+	sess, err := r.su.SessionFromContext(c)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	id, err := r.u.Create(c.Request.Context(), c.Param("login"), sess.UserID, &params)
+	if err != nil {
+		r.collectionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"id": id})
+}
+
+// @Summary     Update collection
+// @Description Update the collection
+// @ID          update collection
+// @Tags  	    collections
+// @Param 		cover formData file false "Cover image"
+// @Param 		collection formData entities.UpdateCollection false "Collection params"
+// @Accept      mpfd
+// @Produce     json
+// @Success     200
+// @Failure     400
+// @Failure     401
+// @Failure     404
+// @Failure     500
+// @Router      /user/{login}/collections/{id} [put]
+func (r *collectionRoutes) update(c *gin.Context) {
+	contentType := c.Request.Header.Get("Content-Type")
+	if !strings.Contains(contentType, "multipart/form-data") {
+		slog.Error(ErrContentType.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": ErrContentType.Error()})
+		return
+	}
+
+	collectionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		slog.Error(ErrCollectionIDType.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": ErrCollectionIDType.Error()})
+		return
+	}
+
+	var params entities.UpdateCollection
+	if err := c.Bind(&params); err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.GetErrMessages(err).Error()})
+		return
+	}
+
+	params.Cover, err = r.getCover(c)
+	if err != nil {
+		r.collectionError(c, err)
+		return
+	}
+
+	sess, err := r.su.SessionFromContext(c)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	err = r.u.Update(c.Request.Context(), c.Param("login"), sess.UserID, collectionID, &params)
+	if err != nil {
+		r.collectionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "collection updated"})
+}
+
+// @Summary     Delete collection
+// @Description Delete the collection, its recipes are kept
+// @ID          delete collection
+// @Tags  	    collections
+// @Produce     json
+// @Success     200
+// @Failure     400
+// @Failure     401
+// @Failure     404
+// @Failure     500
+// @Router      /user/{login}/collections/{id} [delete]
+func (r *collectionRoutes) delete(c *gin.Context) {
+	collectionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		slog.Error(ErrCollectionIDType.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": ErrCollectionIDType.Error()})
+		return
+	}
+
+	sess, err := r.su.SessionFromContext(c)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	err = r.u.Delete(c.Request.Context(), c.Param("login"), sess.UserID, collectionID)
+	if err != nil {
+		r.collectionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "collection deleted"})
+}
+
+// @Summary     Add recipe to collection
+// @Description Add the recipe to the collection at the position or to its end
+// @ID          add recipe to collection
+// @Tags  	    collections
+// @Accept      json
+// @Param 		recipe body entities.CollectionRecipe true "recipe"
+// @Produce     json
+// @Success     200
+// @Failure     400
+// @Failure     401
+// @Failure     404
+// @Failure     500
+// @Router      /user/{login}/collections/{id}/recipes [post]
+func (r *collectionRoutes) addRecipe(c *gin.Context) {
+	collectionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		slog.Error(ErrCollectionIDType.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": ErrCollectionIDType.Error()})
+		return
+	}
+
+	var item entities.CollectionRecipe
+	if err := c.ShouldBindJSON(&item); err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.GetErrMessages(err).Error()})
+		return
+	}
+
+	sess, err := r.su.SessionFromContext(c)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	err = r.u.AddRecipe(c.Request.Context(), c.Param("login"), sess.UserID, collectionID, &item)
+	if err != nil {
+		r.collectionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "recipe added"})
+}
+
+// @Summary     Reorder collection
+// @Description Put the recipes of the collection in the new order. recipe_ids must list every recipe shown in the collection, recipes hidden from the owner go after them.
+// @ID          reorder collection
+// @Tags  	    collections
+// @Accept      json
+// @Param 		order body entities.CollectionOrder true "order"
+// @Produce     json
+// @Success     200
+// @Failure     400
+// @Failure     401
+// @Failure     404
+// @Failure     500
+// @Router      /user/{login}/collections/{id}/recipes [put]
+func (r *collectionRoutes) reorder(c *gin.Context) {
+	collectionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		slog.Error(ErrCollectionIDType.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": ErrCollectionIDType.Error()})
+		return
+	}
+
+	var order entities.CollectionOrder
+	if err := c.ShouldBindJSON(&order); err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.GetErrMessages(err).Error()})
+		return
+	}
+
+	sess, err := r.su.SessionFromContext(c)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	err = r.u.Reorder(c.Request.Context(), c.Param("login"), sess.UserID, collectionID, &order)
+	if err != nil {
+		r.collectionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "collection reordered"})
+}
+
+// @Summary     Remove recipe from collection
+// @Description Remove the recipe from the collection
+// @ID          remove recipe from collection
+// @Tags  	    collections
+// @Produce     json
+// @Success     200
+// @Failure     400
+// @Failure     401
+// @Failure     404
+// @Failure     500
+// @Router      /user/{login}/collections/{id}/recipes/{recipe_id} [delete]
+func (r *collectionRoutes) removeRecipe(c *gin.Context) {
+	collectionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		slog.Error(ErrCollectionIDType.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": ErrCollectionIDType.Error()})
+		return
+	}
+
+	recipeID, err := strconv.Atoi(c.Param("recipe_id"))
+	if err != nil {
+		slog.Error(common.ErrRecipeIDType.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.ErrRecipeIDType.Error()})
+		return
+	}
+
+	sess, err := r.su.SessionFromContext(c)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	err = r.u.RemoveRecipe(c.Request.Context(), c.Param("login"), sess.UserID, collectionID, recipeID)
+	if err != nil {
+		r.collectionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "recipe removed"})
+}
@@ -0,0 +1,218 @@
+package v1
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Homyakadze14/RecipeSite/internal/common"
+	"github.com/Homyakadze14/RecipeSite/internal/entities"
+	"github.com/Homyakadze14/RecipeSite/internal/usecases"
+	"github.com/gin-gonic/gin"
+)
+
+var ErrCookLogIDType = errors.New("cook log ID must be integer")
+
+type cookLogRoutes struct {
+	u  *usecases.CookLogUseCase
+	su *usecases.SessionUseCase
+}
+
+func NewCookLogRoutes(handler *gin.RouterGroup, u *usecases.CookLogUseCase, su *usecases.SessionUseCase) {
+	r := &cookLogRoutes{u, su}
+
+	h := handler.Group("/recipe/:id/cooked")
+	{
+		h.GET("/photos", r.getPhotos)
+		h.POST("", su.Auth(), r.create)
+	}
+
+	ur := handler.Group("/user/:login/cooked")
+	{
+		ur.Use(su.Auth())
+		ur.DELETE("/:id", r.delete)
+	}
+}
+
+func (r *cookLogRoutes) getPhoto(c *gin.Context) (io.ReadSeeker, error) {
+	err := c.Request.ParseMultipartForm(maxFilesSize)
+	if err != nil {
+		return nil, common.ErrHudgeFiles
+	}
+
+	fileHeader, err := c.FormFile("photo")
+
+	if fileHeader == nil {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.Contains(fileHeader.Header.Get("Content-Type"), "image") {
+		return nil, common.ErrImageType
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return file, nil
+}
+
+// cookLogError writes the response for an error of the cook log use case.
+func (r *cookLogRoutes) cookLogError(c *gin.Context, err error) {
+	slog.Error(err.Error())
+	switch {
+	case errors.Is(err, usecases.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": usecases.ErrUserNotFound.Error()})
+	case errors.Is(err, usecases.ErrRecipeNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": usecases.ErrRecipeNotFound.Error()})
+	case errors.Is(err, usecases.ErrCookLogNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": usecases.ErrCookLogNotFound.Error()})
+	case errors.Is(err, usecases.ErrCookedInFuture):
+		c.JSON(http.StatusBadRequest, gin.H{"error": usecases.ErrCookedInFuture.Error()})
+	case errors.Is(err, common.ErrNoPermissions):
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.ErrNoPermissions.Error()})
+	case errors.Is(err, common.ErrHudgeFiles):
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.ErrHudgeFiles.Error()})
+	case errors.Is(err, common.ErrImageType):
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.ErrImageType.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+	}
+}
+
+// @Summary     Get cook photos
+// @Description Get all the photos users took of the cooked recipe, the latest first
+// @ID          get cook photos
+// @Tags  	    cooked
+// @Produce     json
+// @Success     200 {array} entities.CookLog
+// @Failure     400
+// @Failure     404
+// @Failure     500
+// @Router      /recipe/{id}/cooked/photos [get]
+func (r *cookLogRoutes) getPhotos(c *gin.Context) {
+	recipeID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		slog.Error(common.ErrRecipeIDType.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.ErrRecipeIDType.Error()})
+		return
+	}
+
+	userID, err := viewerID(r.su, c)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	logs, err := r.u.GetPhotos(c.Request.Context(), recipeID, userID)
+	if err != nil {
+		r.cookLogError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, logs)
+}
+
+// @Summary     Mark recipe as cooked
+// @Description Mark that the user cooked the recipe, today unless cooked_on is set,
+// @Description with an optional note and photo
+// @ID          mark recipe as cooked
+// @Tags  	    cooked
+// @Param 		photo formData file false "Photo of the dish"
+// @Param 		cooklog formData entities.CreateCookLog false "Cook log params"
+// @Accept      mpfd
+// @Produce     json
+// @Success     200
+// @Failure     400
+// @Failure     401
+// @Failure     404
+// @Failure     500
+// @Router      /recipe/{id}/cooked [post]
+func (r *cookLogRoutes) create(c *gin.Context) {
+	contentType := c.Request.Header.Get("Content-Type")
+	if !strings.Contains(contentType, "multipart/form-data") {
+		slog.Error(ErrContentType.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": ErrContentType.Error()})
+		return
+	}
+
+	recipeID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		slog.Error(common.ErrRecipeIDType.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.ErrRecipeIDType.Error()})
+		return
+	}
+
+	var params entities.CreateCookLog
+	if err := c.Bind(&params); err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.GetErrMessages(err).Error()})
+		return
+	}
+
+	params.Photo, err = r.getPhoto(c)
+	if err != nil {
+		r.cookLogError(c, err)
+		return
+	}
+
+	sess, err := r.su.SessionFromContext(c)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	id, err := r.u.Create(c.Request.Context(), recipeID, sess.UserID, &params)
+	if err != nil {
+		r.cookLogError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"id": id})
+}
+
+// @Summary     Delete cook log
+// @Description Remove the cook log and its photo from the cooking history of the user
+// @ID          delete cook log
+// @Tags  	    cooked
+// @Produce     json
+// @Success     200
+// @Failure     400
+// @Failure     401
+// @Failure     404
+// @Failure     500
+// @Router      /user/{login}/cooked/{id} [delete]
+func (r *cookLogRoutes) delete(c *gin.Context) {
+	logID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		slog.Error(ErrCookLogIDType.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": ErrCookLogIDType.Error()})
+		return
+	}
+
+	sess, err := r.su.SessionFromContext(c)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	err = r.u.Delete(c.Request.Context(), c.Param("login"), sess.UserID, logID)
+	if err != nil {
+		r.cookLogError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "cook log deleted"})
+}
@@ -0,0 +1,57 @@
+package v1
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/Homyakadze14/RecipeSite/internal/common"
+	"github.com/Homyakadze14/RecipeSite/internal/entities"
+	"github.com/Homyakadze14/RecipeSite/internal/usecases"
+	"github.com/gin-gonic/gin"
+)
+
+type labelRoutes struct {
+	u *usecases.LabelUseCase
+}
+
+func NewLabelRoutes(handler *gin.RouterGroup, u *usecases.LabelUseCase) {
+	r := &labelRoutes{u}
+
+	h := handler.Group("/labels")
+	{
+		h.GET("", r.getAll)
+		h.POST("/suggest", r.suggest)
+	}
+}
+
+// @Summary     Get labels
+// @Description Get all allergens and diets recipes can be labeled with
+// @ID          get labels
+// @Tags  	    labels
+// @Produce     json
+// @Success     200 {object} entities.Labels
+// @Router      /labels [get]
+func (r *labelRoutes) getAll(c *gin.Context) {
+	c.JSON(http.StatusOK, r.u.GetAll())
+}
+
+// @Summary     Suggest labels
+// @Description Suggest allergens and diets by ingredients
+// @ID          suggest labels
+// @Tags  	    labels
+// @Accept      json
+// @Param 		ingredients body entities.LabelsSuggestion true "ingredients"
+// @Produce     json
+// @Success     200 {object} entities.Labels
+// @Failure     400
+// @Router      /labels/suggest [post]
+func (r *labelRoutes) suggest(c *gin.Context) {
+	var suggestion entities.LabelsSuggestion
+	if err := c.ShouldBindJSON(&suggestion); err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.GetErrMessages(err).Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, r.u.Suggest(suggestion.Ingredients))
+}
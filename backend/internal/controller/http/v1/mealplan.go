@@ -0,0 +1,247 @@
+package v1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/Homyakadze14/RecipeSite/internal/common"
+	"github.com/Homyakadze14/RecipeSite/internal/entities"
+	"github.com/Homyakadze14/RecipeSite/internal/usecases"
+	"github.com/gin-gonic/gin"
+)
+
+var ErrMealPlanEntryIDType = errors.New("meal plan entry ID must be integer")
+
+type mealPlanRoutes struct {
+	u  *usecases.MealPlanUseCase
+	su *usecases.SessionUseCase
+}
+
+func NewMealPlanRoutes(handler *gin.RouterGroup, u *usecases.MealPlanUseCase, su *usecases.SessionUseCase) {
+	r := &mealPlanRoutes{u, su}
+
+	ur := handler.Group("/user/:login/mealplan")
+	{
+		ur.Use(su.Auth())
+		ur.GET("", r.get)
+		ur.POST("", r.add)
+		ur.POST("/copy", r.copyWeek)
+		ur.PUT("/:id", r.update)
+		ur.DELETE("/:id", r.delete)
+	}
+}
+
+// mealPlanError writes the response for an error of the meal plan use case.
+func (r *mealPlanRoutes) mealPlanError(c *gin.Context, err error) {
+	slog.Error(err.Error())
+	switch {
+	case errors.Is(err, usecases.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": usecases.ErrUserNotFound.Error()})
+	case errors.Is(err, usecases.ErrRecipeNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": usecases.ErrRecipeNotFound.Error()})
+	case errors.Is(err, usecases.ErrMealPlanEntryNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": usecases.ErrMealPlanEntryNotFound.Error()})
+	case errors.Is(err, common.ErrNoPermissions):
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.ErrNoPermissions.Error()})
+	case errors.Is(err, usecases.ErrMealAlreadyPlanned):
+		c.JSON(http.StatusBadRequest, gin.H{"error": usecases.ErrMealAlreadyPlanned.Error()})
+	case errors.Is(err, usecases.ErrMealPlanRange):
+		c.JSON(http.StatusBadRequest, gin.H{"error": usecases.ErrMealPlanRange.Error()})
+	case errors.Is(err, usecases.ErrMealPlanCopy):
+		c.JSON(http.StatusBadRequest, gin.H{"error": usecases.ErrMealPlanCopy.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+	}
+}
+
+// @Summary     Get meal plan
+// @Description Get the meal plan for the days from "from" to "to" inclusive, at most 62 days
+// @ID          get meal plan
+// @Tags  	    mealplan
+// @Param 		range query entities.MealPlanRange true "range"
+// @Produce     json
+// @Success     200 {object} entities.MealPlan
+// @Failure     400
+// @Failure     401
+// @Failure     404
+// @Failure     500
+// @Router      /user/{login}/mealplan [get]
+func (r *mealPlanRoutes) get(c *gin.Context) {
+	var params entities.MealPlanRange
+	if err := c.ShouldBindQuery(&params); err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.GetErrMessages(err).Error()})
+		return
+	}
+
+	sess, err := r.su.SessionFromContext(c)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	plan, err := r.u.Get(c.Request.Context(), c.Param("login"), sess.UserID, &params)
+	if err != nil {
+		r.mealPlanError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, plan)
+}
+
+// @Summary     Plan meal
+// @Description Put the recipe into the breakfast, lunch or dinner slot on the date
+// @ID          plan meal
+// @Tags  	    mealplan
+// @Accept      json
+// @Param 		entry body entities.AddMealPlanEntry true "entry"
+// @Produce     json
+// @Success     200
+// @Failure     400
+// @Failure     401
+// @Failure     404
+// @Failure     500
+// @Router      /user/{login}/mealplan [post]
+func (r *mealPlanRoutes) add(c *gin.Context) {
+	var params entities.AddMealPlanEntry
+	if err := c.ShouldBindJSON(&params); err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.GetErrMessages(err).Error()})
+		return
+	}
+
+	sess, err := r.su.SessionFromContext(c)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	id, err := r.u.Add(c.Request.Context(), c.Param("login"), sess.UserID, &params)
+	if err != nil {
+		r.mealPlanError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"id": id})
+}
+
+// @Summary     Update planned meal
+// @Description Move the planned meal to another slot or date or change its servings
+// @ID          update planned meal
+// @Tags  	    mealplan
+// @Accept      json
+// @Param 		entry body entities.UpdateMealPlanEntry true "entry"
+// @Produce     json
+// @Success     200
+// @Failure     400
+// @Failure     401
+// @Failure     404
+// @Failure     500
+// @Router      /user/{login}/mealplan/{id} [put]
+func (r *mealPlanRoutes) update(c *gin.Context) {
+	entryID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		slog.Error(ErrMealPlanEntryIDType.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": ErrMealPlanEntryIDType.Error()})
+		return
+	}
+
+	var params entities.UpdateMealPlanEntry
+	if err := c.ShouldBindJSON(&params); err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.GetErrMessages(err).Error()})
+		return
+	}
+
+	sess, err := r.su.SessionFromContext(c)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	err = r.u.Update(c.Request.Context(), c.Param("login"), sess.UserID, entryID, &params)
+	if err != nil {
+		r.mealPlanError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "meal updated"})
+}
+
+// @Summary     Delete planned meal
+// @Description Remove the meal from the plan
+// @ID          delete planned meal
+// @Tags  	    mealplan
+// @Produce     json
+// @Success     200
+// @Failure     400
+// @Failure     401
+// @Failure     404
+// @Failure     500
+// @Router      /user/{login}/mealplan/{id} [delete]
+func (r *mealPlanRoutes) delete(c *gin.Context) {
+	entryID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		slog.Error(ErrMealPlanEntryIDType.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": ErrMealPlanEntryIDType.Error()})
+		return
+	}
+
+	sess, err := r.su.SessionFromContext(c)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	err = r.u.Delete(c.Request.Context(), c.Param("login"), sess.UserID, entryID)
+	if err != nil {
+		r.mealPlanError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "meal deleted"})
+}
+
+// @Summary     Copy meal plan week
+// @Description Copy the seven days starting at "from" to the seven days starting at "to".
+// @Description Meals already planned in the target week are kept.
+// @ID          copy meal plan week
+// @Tags  	    mealplan
+// @Accept      json
+// @Param 		copy body entities.MealPlanCopy true "weeks"
+// @Produce     json
+// @Success     200
+// @Failure     400
+// @Failure     401
+// @Failure     404
+// @Failure     500
+// @Router      /user/{login}/mealplan/copy [post]
+func (r *mealPlanRoutes) copyWeek(c *gin.Context) {
+	var params entities.MealPlanCopy
+	if err := c.ShouldBindJSON(&params); err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.GetErrMessages(err).Error()})
+		return
+	}
+
+	sess, err := r.su.SessionFromContext(c)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	copied, err := r.u.CopyWeek(c.Request.Context(), c.Param("login"), sess.UserID, &params)
+	if err != nil {
+		r.mealPlanError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"copied": copied})
+}
@@ -0,0 +1,193 @@
+package v1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/Homyakadze14/RecipeSite/internal/common"
+	"github.com/Homyakadze14/RecipeSite/internal/entities"
+	"github.com/Homyakadze14/RecipeSite/internal/usecases"
+	"github.com/gin-gonic/gin"
+)
+
+var ErrPantryItemIDType = errors.New("pantry item ID must be integer")
+
+type pantryRoutes struct {
+	u  *usecases.PantryUseCase
+	su *usecases.SessionUseCase
+}
+
+func NewPantryRoutes(handler *gin.RouterGroup, u *usecases.PantryUseCase, su *usecases.SessionUseCase) {
+	r := &pantryRoutes{u, su}
+
+	h := handler.Group("/recipe")
+	{
+		h.POST("/by-ingredients", r.findRecipes)
+	}
+
+	ur := handler.Group("/user/:login/pantry")
+	{
+		ur.Use(su.Auth())
+		ur.GET("", r.getAll)
+		ur.POST("", r.add)
+		ur.DELETE("/:id", r.delete)
+	}
+}
+
+// pantryError writes the response for an error of the pantry use case.
+func (r *pantryRoutes) pantryError(c *gin.Context, err error) {
+	slog.Error(err.Error())
+	switch {
+	case errors.Is(err, usecases.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": usecases.ErrUserNotFound.Error()})
+	case errors.Is(err, usecases.ErrPantryItemNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": usecases.ErrPantryItemNotFound.Error()})
+	case errors.Is(err, common.ErrNoPermissions):
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.ErrNoPermissions.Error()})
+	case errors.Is(err, usecases.ErrNothingAtHand):
+		c.JSON(http.StatusBadRequest, gin.H{"error": usecases.ErrNothingAtHand.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+	}
+}
+
+// @Summary     Find recipes by ingredients
+// @Description Rank recipes by how many of their ingredients are at hand, the ones with fewer
+// @Description missing ingredients go first. The pantry of the signed in user is searched
+// @Description together with the given ingredients. An ingredient is at hand when one of the
+// @Description names is a whole word of it, "salt" matches "sea salt" but not "unsalted butter".
+// @ID          find recipes by ingredients
+// @Tags  	    pantry
+// @Accept      json
+// @Param 		search body entities.IngredientSearch true "search"
+// @Produce     json
+// @Success     200 {object} entities.RecipeMatchList
+// @Failure     400
+// @Failure     500
+// @Router      /recipe/by-ingredients [post]
+func (r *pantryRoutes) findRecipes(c *gin.Context) {
+	var params entities.IngredientSearch
+	if err := c.ShouldBindJSON(&params); err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.GetErrMessages(err).Error()})
+		return
+	}
+
+	userID, err := viewerID(r.su, c)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+	params.ViewerID = userID
+
+	recipes, err := r.u.FindRecipes(c.Request.Context(), &params)
+	if err != nil {
+		r.pantryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, recipes)
+}
+
+// @Summary     Get pantry
+// @Description Get the ingredients the user has at home
+// @ID          get pantry
+// @Tags  	    pantry
+// @Produce     json
+// @Success     200 {array} entities.PantryItem
+// @Failure     400
+// @Failure     401
+// @Failure     404
+// @Failure     500
+// @Router      /user/{login}/pantry [get]
+func (r *pantryRoutes) getAll(c *gin.Context) {
+	sess, err := r.su.SessionFromContext(c)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	items, err := r.u.GetAll(c.Request.Context(), c.Param("login"), sess.UserID)
+	if err != nil {
+		r.pantryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, items)
+}
+
+// @Summary     Add to pantry
+// @Description Put the ingredients into the pantry, the ones which are there already are skipped
+// @ID          add to pantry
+// @Tags  	    pantry
+// @Accept      json
+// @Param 		items body entities.AddPantryItems true "items"
+// @Produce     json
+// @Success     200
+// @Failure     400
+// @Failure     401
+// @Failure     404
+// @Failure     500
+// @Router      /user/{login}/pantry [post]
+func (r *pantryRoutes) add(c *gin.Context) {
+	var params entities.AddPantryItems
+	if err := c.ShouldBindJSON(&params); err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.GetErrMessages(err).Error()})
+		return
+	}
+
+	sess, err := r.su.SessionFromContext(c)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	added, err := r.u.Add(c.Request.Context(), c.Param("login"), sess.UserID, &params)
+	if err != nil {
+		r.pantryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"added": added})
+}
+
+// @Summary     Delete from pantry
+// @Description Remove the ingredient from the pantry
+// @ID          delete from pantry
+// @Tags  	    pantry
+// @Produce     json
+// @Success     200
+// @Failure     400
+// @Failure     401
+// @Failure     404
+// @Failure     500
+// @Router      /user/{login}/pantry/{id} [delete]
+func (r *pantryRoutes) delete(c *gin.Context) {
+	itemID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		slog.Error(ErrPantryItemIDType.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": ErrPantryItemIDType.Error()})
+		return
+	}
+
+	sess, err := r.su.SessionFromContext(c)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	err = r.u.Delete(c.Request.Context(), c.Param("login"), sess.UserID, itemID)
+	if err != nil {
+		r.pantryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "item deleted"})
+}
@@ -0,0 +1,168 @@
+package v1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/Homyakadze14/RecipeSite/internal/common"
+	"github.com/Homyakadze14/RecipeSite/internal/entities"
+	"github.com/Homyakadze14/RecipeSite/internal/usecases"
+	"github.com/gin-gonic/gin"
+)
+
+type ratingRoutes struct {
+	u  *usecases.RatingUseCase
+	su *usecases.SessionUseCase
+}
+
+func NewRatingRoutes(handler *gin.RouterGroup, u *usecases.RatingUseCase, su *usecases.SessionUseCase) {
+	r := &ratingRoutes{u, su}
+
+	h := handler.Group("/recipe/:id")
+	{
+		h.GET("/ratings", r.getAll)
+		h.PUT("/rating", su.Auth(), r.rate)
+		h.DELETE("/rating", su.Auth(), r.delete)
+	}
+}
+
+// ratingError writes the response for an error of the rating use case.
+func (r *ratingRoutes) ratingError(c *gin.Context, err error) {
+	slog.Error(err.Error())
+	switch {
+	case errors.Is(err, usecases.ErrRecipeNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": usecases.ErrRecipeNotFound.Error()})
+	case errors.Is(err, usecases.ErrRatingNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": usecases.ErrRatingNotFound.Error()})
+	case errors.Is(err, usecases.ErrRateOwnRecipe):
+		c.JSON(http.StatusBadRequest, gin.H{"error": usecases.ErrRateOwnRecipe.Error()})
+	case errors.Is(err, usecases.ErrBadCursor):
+		c.JSON(http.StatusBadRequest, gin.H{"error": usecases.ErrBadCursor.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+	}
+}
+
+// @Summary     Get recipe ratings
+// @Description Get a page of the ratings and reviews of the recipe, the latest first
+// @ID          get recipe ratings
+// @Tags  	    ratings
+// @Param 		page query entities.RatingPage false "page"
+// @Produce     json
+// @Success     200 {object} entities.RatingList
+// @Failure     400
+// @Failure     404
+// @Failure     500
+// @Router      /recipe/{id}/ratings [get]
+func (r *ratingRoutes) getAll(c *gin.Context) {
+	recipeID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		slog.Error(common.ErrRecipeIDType.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.ErrRecipeIDType.Error()})
+		return
+	}
+
+	var page entities.RatingPage
+	if err := c.ShouldBindQuery(&page); err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.GetErrMessages(err).Error()})
+		return
+	}
+
+	userID, err := viewerID(r.su, c)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	ratings, err := r.u.GetAll(c.Request.Context(), recipeID, userID, &page)
+	if err != nil {
+		r.ratingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ratings)
+}
+
+// @Summary     Rate recipe
+// @Description Give the recipe from 1 to 5 stars with an optional review. Rating the recipe
+// @Description again replaces the previous rating. Authors can't rate their own recipes.
+// @ID          rate recipe
+// @Tags  	    ratings
+// @Accept      json
+// @Param 		rating body entities.RateRecipe true "rating"
+// @Produce     json
+// @Success     200
+// @Failure     400
+// @Failure     401
+// @Failure     404
+// @Failure     500
+// @Router      /recipe/{id}/rating [put]
+func (r *ratingRoutes) rate(c *gin.Context) {
+	recipeID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		slog.Error(common.ErrRecipeIDType.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.ErrRecipeIDType.Error()})
+		return
+	}
+
+	var params entities.RateRecipe
+	if err := c.ShouldBindJSON(&params); err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.GetErrMessages(err).Error()})
+		return
+	}
+
+	sess, err := r.su.SessionFromContext(c)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	id, err := r.u.Rate(c.Request.Context(), recipeID, sess.UserID, &params)
+	if err != nil {
+		r.ratingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"id": id})
+}
+
+// @Summary     Delete rating
+// @Description Remove the rating and the review the user gave to the recipe
+// @ID          delete rating
+// @Tags  	    ratings
+// @Produce     json
+// @Success     200
+// @Failure     400
+// @Failure     401
+// @Failure     404
+// @Failure     500
+// @Router      /recipe/{id}/rating [delete]
+func (r *ratingRoutes) delete(c *gin.Context) {
+	recipeID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		slog.Error(common.ErrRecipeIDType.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.ErrRecipeIDType.Error()})
+		return
+	}
+
+	sess, err := r.su.SessionFromContext(c)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	err = r.u.Delete(c.Request.Context(), recipeID, sess.UserID)
+	if err != nil {
+		r.ratingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "rating deleted"})
+}
@@ -1,7 +1,9 @@
 package v1
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
@@ -12,11 +14,25 @@ import (
 	"github.com/Homyakadze14/RecipeSite/internal/entities"
 	"github.com/Homyakadze14/RecipeSite/internal/usecases"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 )
 
 const (
 	maxFilesSize    = 10 << 20
 	photosArrLenght = 5
+	maxIngredients  = 50
+	maxSteps        = 50
+	stepPhotoField  = "step_photo_"
+)
+
+var (
+	ErrIngredientsFormat  = errors.New("ingredients must be a JSON array of objects")
+	ErrTooManyIngredients = fmt.Errorf("maximum count of ingredients is %v", maxIngredients)
+	ErrStepsFormat        = errors.New("steps must be a JSON array of objects")
+	ErrTooManySteps       = fmt.Errorf("maximum count of steps is %v", maxSteps)
+	ErrStepPhotoField     = fmt.Errorf("step photos must be sent as %sN where N is the step number", stepPhotoField)
+	ErrComplexityRange    = errors.New("complexity_min must not be greater than complexity_max")
+	ErrCreatedRange       = errors.New("created_after must be before created_before")
 )
 
 type recipeRoutes struct {
@@ -33,6 +49,7 @@ func NewRecipeRoutes(handler *gin.RouterGroup, u *usecases.RecipeUseCases, su *u
 		h.POST("", r.getFiltered)
 		h.GET("/:id", r.get)
 		h.POST("/author", r.getAuthor)
+		h.POST("/:id/fork", su.Auth(), r.fork)
 	}
 
 	ur := handler.Group("/user/:login/recipe")
@@ -41,6 +58,7 @@ func NewRecipeRoutes(handler *gin.RouterGroup, u *usecases.RecipeUseCases, su *u
 		ur.POST("", r.create)
 		ur.PUT("/:id", r.update)
 		ur.DELETE("/:id", r.delete)
+		ur.PUT("/:id/status", r.setStatus)
 	}
 }
 
@@ -48,19 +66,34 @@ func NewRecipeRoutes(handler *gin.RouterGroup, u *usecases.RecipeUseCases, su *u
 // @Description Get all recipe
 // @ID          get all recipe
 // @Tags  	    recipe
+// @Param 		page query entities.RecipePage false "page"
 // @Produce     json
-// @Success     200 {object} []entities.RecipeWithAuthor
+// @Success     200 {object} entities.RecipeList
+// @Header      200 {integer} X-Total-Count "Count of all recipes"
+// @Failure     400
 // @Failure     500
 // @Router      /recipe [get]
 func (r *recipeRoutes) getAll(c *gin.Context) {
-	recipes, err := r.u.GetAll(c.Request.Context())
+	var page entities.RecipePage
+	if err := c.ShouldBindQuery(&page); err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.GetErrMessages(err).Error()})
+		return
+	}
+
+	recipes, err := r.u.GetAll(c.Request.Context(), &page)
 	if err != nil {
 		slog.Error(err.Error())
+		if errors.Is(err, usecases.ErrBadCursor) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": usecases.ErrBadCursor.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"recipes": recipes})
+	c.Header("X-Total-Count", strconv.Itoa(recipes.Total))
+	c.JSON(http.StatusOK, recipes)
 }
 
 // @Summary     Get filtered recipe
@@ -70,7 +103,7 @@ func (r *recipeRoutes) getAll(c *gin.Context) {
 // @Accept      json
 // @Param 		filter body entities.RecipeFilter false "filter"
 // @Produce     json
-// @Success     200 {object} []entities.RecipeWithAuthor
+// @Success     200 {object} entities.RecipeList
 // @Failure     400
 // @Failure     500
 // @Router      /recipe [post]
@@ -81,6 +114,29 @@ func (r *recipeRoutes) getFiltered(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": common.GetErrMessages(err).Error()})
 		return
 	}
+	if filter == nil {
+		filter = &entities.RecipeFilter{}
+	}
+
+	if err := r.validateFilter(filter); err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if filter.Status != "" && filter.Status != entities.StatusPublished {
+		sess, err := r.su.GetSession(c.Request)
+		if err != nil {
+			if errors.Is(err, usecases.ErrUnauth) {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": usecases.ErrUnauth.Error()})
+				return
+			}
+			slog.Error(err.Error())
+			c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+			return
+		}
+		filter.ViewerID = sess.UserID
+	}
 
 	recipes, err := r.u.GetFiltered(c.Request.Context(), filter)
 	if err != nil {
@@ -89,17 +145,22 @@ func (r *recipeRoutes) getFiltered(c *gin.Context) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": usecases.ErrBadOrderField.Error()})
 			return
 		}
+		if errors.Is(err, usecases.ErrBadCursor) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": usecases.ErrBadCursor.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"recipes": recipes})
+	c.JSON(http.StatusOK, recipes)
 }
 
 // @Summary     Get recipe
 // @Description Get recipe
 // @ID          get recipe
 // @Tags  	    recipe
+// @Param 		params query entities.RecipeViewParams false "view params"
 // @Produce     json
 // @Success     200 {object} entities.RecipeInfo
 // @Failure     400
@@ -121,6 +182,13 @@ func (r *recipeRoutes) get(c *gin.Context) {
 		return
 	}
 
+	var params entities.RecipeViewParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.GetErrMessages(err).Error()})
+		return
+	}
+
 	sess, err := r.su.GetSession(c.Request)
 	authorized := true
 	userID := 0
@@ -137,7 +205,7 @@ func (r *recipeRoutes) get(c *gin.Context) {
 		userID = sess.UserID
 	}
 
-	recipe, err := r.u.Get(c.Request.Context(), recipeID, userID, authorized)
+	recipe, err := r.u.Get(c.Request.Context(), recipeID, userID, authorized, &params)
 	if err != nil {
 		slog.Error(err.Error())
 		if errors.Is(err, usecases.ErrRecipeNotFound) {
@@ -219,11 +287,102 @@ func (r *recipeRoutes) getPhotos(c *gin.Context) ([]io.ReadSeeker, error) {
 	return photos, nil
 }
 
+func (r *recipeRoutes) validateFilter(filter *entities.RecipeFilter) error {
+	if filter.ComplexityMin != 0 && filter.ComplexityMax != 0 && filter.ComplexityMin > filter.ComplexityMax {
+		return ErrComplexityRange
+	}
+	if filter.CreatedAfter != nil && filter.CreatedBefore != nil && !filter.CreatedAfter.Before(*filter.CreatedBefore) {
+		return ErrCreatedRange
+	}
+	return nil
+}
+
+func (r *recipeRoutes) parseIngredients(raw string) ([]entities.Ingredient, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	ingredients := make([]entities.Ingredient, 0, maxIngredients)
+	if err := json.Unmarshal([]byte(raw), &ingredients); err != nil {
+		return nil, ErrIngredientsFormat
+	}
+
+	if len(ingredients) > maxIngredients {
+		return nil, ErrTooManyIngredients
+	}
+
+	for i := range ingredients {
+		if err := binding.Validator.ValidateStruct(&ingredients[i]); err != nil {
+			return nil, common.GetErrMessages(err)
+		}
+	}
+
+	return ingredients, nil
+}
+
+func (r *recipeRoutes) parseSteps(raw string) ([]entities.RecipeStep, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	steps := make([]entities.RecipeStep, 0, maxSteps)
+	if err := json.Unmarshal([]byte(raw), &steps); err != nil {
+		return nil, ErrStepsFormat
+	}
+
+	if len(steps) > maxSteps {
+		return nil, ErrTooManySteps
+	}
+
+	for i := range steps {
+		if err := binding.Validator.ValidateStruct(&steps[i]); err != nil {
+			return nil, common.GetErrMessages(err)
+		}
+	}
+
+	return steps, nil
+}
+
+// getStepPhotos collects files sent as step_photo_N, keyed by the step index.
+func (r *recipeRoutes) getStepPhotos(c *gin.Context) (map[int]io.ReadSeeker, error) {
+	multipartFormData, err := c.MultipartForm()
+	if err != nil {
+		return nil, err
+	}
+
+	photos := make(map[int]io.ReadSeeker)
+	for field, files := range multipartFormData.File {
+		if !strings.HasPrefix(field, stepPhotoField) || len(files) == 0 {
+			continue
+		}
+
+		number, err := strconv.Atoi(strings.TrimPrefix(field, stepPhotoField))
+		if err != nil || number < 1 {
+			return nil, ErrStepPhotoField
+		}
+
+		fileHeader := files[0]
+		if !strings.Contains(fileHeader.Header.Get("Content-Type"), "image") {
+			return nil, common.ErrImageType
+		}
+
+		file, err := fileHeader.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		photos[number-1] = file
+	}
+
+	return photos, nil
+}
+
 // @Summary     Create recipe
 // @Description Create recipe
 // @ID          create recipe
 // @Tags  	    recipe
 // @Param 		photos formData file false "Photos"
+// @Param 		step_photo_1 formData file false "Photo of a step, send step_photo_N for the N-th step"
 // @Param 		recipe formData entities.CreateRecipe false "Recipe params"
 // @Accept      mpfd
 // @Produce     json
@@ -258,6 +417,28 @@ func (r *recipeRoutes) create(c *gin.Context) {
 		return
 	}
 
+	if err := params.CookingTime.Parse(); err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ingredients, err := r.parseIngredients(params.RawIngredients)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	params.Ingredients = ingredients
+
+	steps, err := r.parseSteps(params.RawSteps)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	params.Steps = steps
+
 	photos, err := r.getPhotos(c)
 	if err != nil {
 		slog.Error(err.Error())
@@ -274,6 +455,22 @@ func (r *recipeRoutes) create(c *gin.Context) {
 	}
 	params.Photos = photos
 
+	stepPhotos, err := r.getStepPhotos(c)
+	if err != nil {
+		slog.Error(err.Error())
+		if errors.Is(err, ErrStepPhotoField) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": ErrStepPhotoField.Error()})
+			return
+		}
+		if errors.Is(err, common.ErrImageType) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": common.ErrImageType.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+	params.StepPhotos = stepPhotos
+
 	sess, err := r.su.SessionFromContext(c)
 	if err != nil {
 		slog.Error(err.Error())
@@ -296,6 +493,30 @@ func (r *recipeRoutes) create(c *gin.Context) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": usecases.ErrEmptyPhotos.Error()})
 			return
 		}
+		if errors.Is(err, usecases.ErrEmptyIngredients) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": usecases.ErrEmptyIngredients.Error()})
+			return
+		}
+		if errors.Is(err, usecases.ErrEmptyInstructions) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": usecases.ErrEmptyInstructions.Error()})
+			return
+		}
+		if errors.Is(err, usecases.ErrEmptyCookingTime) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": usecases.ErrEmptyCookingTime.Error()})
+			return
+		}
+		if errors.Is(err, usecases.ErrStepPhotoIndex) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": usecases.ErrStepPhotoIndex.Error()})
+			return
+		}
+		if errors.Is(err, usecases.ErrTagNotFound) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": usecases.ErrTagNotFound.Error()})
+			return
+		}
+		if entities.IsBadStatusChange(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "server error"})
 		return
 	}
@@ -308,6 +529,7 @@ func (r *recipeRoutes) create(c *gin.Context) {
 // @ID          update recipe
 // @Tags  	    recipe
 // @Param 		photos formData file false "Photos"
+// @Param 		step_photo_1 formData file false "Photo of a step, send step_photo_N for the N-th step"
 // @Param 		recipe formData entities.UpdateRecipe false "Recipe params"
 // @Accept      mpfd
 // @Produce     json
@@ -353,6 +575,28 @@ func (r *recipeRoutes) update(c *gin.Context) {
 		return
 	}
 
+	if err := params.CookingTime.Parse(); err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ingredients, err := r.parseIngredients(params.RawIngredients)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	params.Ingredients = ingredients
+
+	steps, err := r.parseSteps(params.RawSteps)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	params.Steps = steps
+
 	photos, err := r.getPhotos(c)
 	if err != nil {
 		slog.Error(err.Error())
@@ -369,6 +613,22 @@ func (r *recipeRoutes) update(c *gin.Context) {
 	}
 	params.Photos = photos
 
+	stepPhotos, err := r.getStepPhotos(c)
+	if err != nil {
+		slog.Error(err.Error())
+		if errors.Is(err, ErrStepPhotoField) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": ErrStepPhotoField.Error()})
+			return
+		}
+		if errors.Is(err, common.ErrImageType) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": common.ErrImageType.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+	params.StepPhotos = stepPhotos
+
 	sess, err := r.su.SessionFromContext(c)
 	if err != nil {
 		slog.Error(err.Error())
@@ -391,6 +651,14 @@ func (r *recipeRoutes) update(c *gin.Context) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": common.ErrNoPermissions.Error()})
 			return
 		}
+		if errors.Is(err, usecases.ErrStepPhotoIndex) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": usecases.ErrStepPhotoIndex.Error()})
+			return
+		}
+		if errors.Is(err, usecases.ErrTagNotFound) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": usecases.ErrTagNotFound.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
 		return
 	}
@@ -459,3 +727,121 @@ func (r *recipeRoutes) delete(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"status": "recipe deleted"})
 }
+
+// @Summary     Change recipe status
+// @Description Publish, schedule or archive the recipe or return it to drafts.
+// @Description Subscribers are notified when the recipe is published for the first time.
+// @ID          change recipe status
+// @Tags  	    recipe
+// @Accept      json
+// @Param 		status body entities.RecipeStatusChange true "status"
+// @Produce     json
+// @Success     200
+// @Failure     400
+// @Failure     401
+// @Failure    	404
+// @Failure     500
+// @Router      /user/{login}/recipe/{id}/status [put]
+func (r *recipeRoutes) setStatus(c *gin.Context) {
+	login, ok := c.Params.Get("login")
+	if !ok {
+		slog.Error(common.ErrLoginProvided.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.ErrLoginProvided.Error()})
+		return
+	}
+
+	recipeID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		slog.Error(common.ErrRecipeIDType.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.ErrRecipeIDType.Error()})
+		return
+	}
+
+	var change entities.RecipeStatusChange
+	if err := c.ShouldBindJSON(&change); err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.GetErrMessages(err).Error()})
+		return
+	}
+
+	sess, err := r.su.SessionFromContext(c)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	err = r.u.SetStatus(c, login, sess.UserID, recipeID, &change)
+	if err != nil {
+		slog.Error(err.Error())
+		if errors.Is(err, usecases.ErrUserNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": usecases.ErrUserNotFound.Error()})
+			return
+		}
+		if errors.Is(err, usecases.ErrRecipeNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": usecases.ErrRecipeNotFound.Error()})
+			return
+		}
+		if errors.Is(err, common.ErrNoPermissions) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": common.ErrNoPermissions.Error()})
+			return
+		}
+		if entities.IsBadStatusChange(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "recipe status changed"})
+}
+
+// @Summary     Fork recipe
+// @Description Copy the recipe into your account as a draft to cook your own version of it.
+// @Description Photos are shared with the original recipe and its author is notified.
+// @ID          fork recipe
+// @Tags  	    recipe
+// @Produce     json
+// @Success     200
+// @Failure     400
+// @Failure     401
+// @Failure    	404
+// @Failure     500
+// @Router      /recipe/{id}/fork [post]
+func (r *recipeRoutes) fork(c *gin.Context) {
+	recipeID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		slog.Error(common.ErrRecipeIDType.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.ErrRecipeIDType.Error()})
+		return
+	}
+
+	sess, err := r.su.SessionFromContext(c)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	forkID, err := r.u.Fork(c, sess.UserID, recipeID)
+	if err != nil {
+		slog.Error(err.Error())
+		if errors.Is(err, usecases.ErrRecipeNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": usecases.ErrRecipeNotFound.Error()})
+			return
+		}
+		if errors.Is(err, usecases.ErrForkOwnRecipe) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": usecases.ErrForkOwnRecipe.Error()})
+			return
+		}
+		if errors.Is(err, usecases.ErrUserNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": usecases.ErrUserNotFound.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"id": forkID})
+}
@@ -0,0 +1,208 @@
+package v1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/Homyakadze14/RecipeSite/internal/common"
+	"github.com/Homyakadze14/RecipeSite/internal/entities"
+	"github.com/Homyakadze14/RecipeSite/internal/usecases"
+	"github.com/gin-gonic/gin"
+)
+
+var ErrRevisionVersionType = errors.New("version must be integer")
+
+type revisionRoutes struct {
+	u  *usecases.RevisionUseCase
+	su *usecases.SessionUseCase
+}
+
+func NewRevisionRoutes(handler *gin.RouterGroup, u *usecases.RevisionUseCase, su *usecases.SessionUseCase) {
+	r := &revisionRoutes{u, su}
+
+	h := handler.Group("/recipe/:id/revisions")
+	{
+		h.GET("", r.getAll)
+		h.GET("/diff", r.diff)
+	}
+
+	ur := handler.Group("/user/:login/recipe/:id/revisions")
+	{
+		ur.Use(su.Auth())
+		ur.POST("/:version/restore", r.restore)
+	}
+}
+
+// viewerID is the id of the signed in user or 0 for guests.
+func viewerID(su *usecases.SessionUseCase, c *gin.Context) (int, error) {
+	sess, err := su.GetSession(c.Request)
+	if err != nil {
+		if errors.Is(err, usecases.ErrUnauth) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return sess.UserID, nil
+}
+
+// @Summary     Get recipe revisions
+// @Description Get previous versions of the recipe, the newest first
+// @ID          get recipe revisions
+// @Tags  	    revisions
+// @Produce     json
+// @Success     200 {object} []entities.RecipeRevision
+// @Failure     400
+// @Failure     404
+// @Failure     500
+// @Router      /recipe/{id}/revisions [get]
+func (r *revisionRoutes) getAll(c *gin.Context) {
+	recipeID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		slog.Error(common.ErrRecipeIDType.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.ErrRecipeIDType.Error()})
+		return
+	}
+
+	userID, err := viewerID(r.su, c)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	revisions, err := r.u.GetAll(c.Request.Context(), recipeID, userID)
+	if err != nil {
+		slog.Error(err.Error())
+		if errors.Is(err, usecases.ErrRecipeNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": usecases.ErrRecipeNotFound.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"revisions": revisions})
+}
+
+// @Summary     Diff recipe revisions
+// @Description Get fields changed between two revisions. Without "to" the revision is compared with the current recipe. Photos are not compared.
+// @ID          diff recipe revisions
+// @Tags  	    revisions
+// @Param 		params query entities.RevisionDiffParams true "revisions"
+// @Produce     json
+// @Success     200 {object} entities.RecipeDiff
+// @Failure     400
+// @Failure     404
+// @Failure     500
+// @Router      /recipe/{id}/revisions/diff [get]
+func (r *revisionRoutes) diff(c *gin.Context) {
+	recipeID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		slog.Error(common.ErrRecipeIDType.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.ErrRecipeIDType.Error()})
+		return
+	}
+
+	var params entities.RevisionDiffParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.GetErrMessages(err).Error()})
+		return
+	}
+
+	userID, err := viewerID(r.su, c)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	diff, err := r.u.Diff(c.Request.Context(), recipeID, userID, &params)
+	if err != nil {
+		slog.Error(err.Error())
+		if errors.Is(err, usecases.ErrRecipeNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": usecases.ErrRecipeNotFound.Error()})
+			return
+		}
+		if errors.Is(err, usecases.ErrRevisionNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": usecases.ErrRevisionNotFound.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+// @Summary     Restore recipe revision
+// @Description Bring the recipe back to the revision. Photos of the recipe are kept as they are.
+// @ID          restore recipe revision
+// @Tags  	    revisions
+// @Produce     json
+// @Success     200
+// @Failure     400
+// @Failure     401
+// @Failure     404
+// @Failure     500
+// @Router      /user/{login}/recipe/{id}/revisions/{version}/restore [post]
+func (r *revisionRoutes) restore(c *gin.Context) {
+	login, ok := c.Params.Get("login")
+	if !ok {
+		slog.Error(common.ErrLoginProvided.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.ErrLoginProvided.Error()})
+		return
+	}
+
+	recipeID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		slog.Error(common.ErrRecipeIDType.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.ErrRecipeIDType.Error()})
+		return
+	}
+
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil {
+		slog.Error(ErrRevisionVersionType.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": ErrRevisionVersionType.Error()})
+		return
+	}
+
+	sess, err := r.su.SessionFromContext(c)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	err = r.u.Restore(c, login, sess.UserID, recipeID, version)
+	if err != nil {
+		slog.Error(err.Error())
+		if errors.Is(err, usecases.ErrUserNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": usecases.ErrUserNotFound.Error()})
+			return
+		}
+		if errors.Is(err, usecases.ErrRecipeNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": usecases.ErrRecipeNotFound.Error()})
+			return
+		}
+		if errors.Is(err, usecases.ErrRevisionNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": usecases.ErrRevisionNotFound.Error()})
+			return
+		}
+		if errors.Is(err, common.ErrNoPermissions) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": common.ErrNoPermissions.Error()})
+			return
+		}
+		if errors.Is(err, usecases.ErrTagNotFound) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": usecases.ErrTagNotFound.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "recipe restored"})
+}
@@ -26,10 +26,22 @@ func NewRouter(handler *gin.Engine,
 	like *usecases.LikeUseCase,
 	recipe *usecases.RecipeUseCases,
 	comment *usecases.CommentUseCase,
-	subscribe *usecases.SubscribeUseCases) {
+	subscribe *usecases.SubscribeUseCases,
+	tag *usecases.TagUseCase,
+	label *usecases.LabelUseCase,
+	revision *usecases.RevisionUseCase,
+	trash *usecases.TrashUseCase,
+	collection *usecases.CollectionUseCase,
+	bookmark *usecases.BookmarkUseCase,
+	mealPlan *usecases.MealPlanUseCase,
+	shoppingList *usecases.ShoppingListUseCase,
+	pantry *usecases.PantryUseCase,
+	rating *usecases.RatingUseCase,
+	cookLog *usecases.CookLogUseCase) {
 	// Options
 	handler.Use(gin.Logger())
 	handler.Use(gin.Recovery())
+	registerValidators()
 
 	// Set cors
 	corsConf := cors.DefaultConfig()
@@ -55,5 +67,16 @@ func NewRouter(handler *gin.Engine,
 		NewRecipeRoutes(h, recipe, sess)
 		NewCommentRoutes(h, comment, sess)
 		NewSubscribeRoutes(h, subscribe, sess)
+		NewTagRoutes(h, tag)
+		NewLabelRoutes(h, label)
+		NewRevisionRoutes(h, revision, sess)
+		NewTrashRoutes(h, trash, sess)
+		NewCollectionRoutes(h, collection, sess)
+		NewBookmarkRoutes(h, bookmark, sess)
+		NewMealPlanRoutes(h, mealPlan, sess)
+		NewShoppingListRoutes(h, shoppingList, sess)
+		NewPantryRoutes(h, pantry, sess)
+		NewRatingRoutes(h, rating, sess)
+		NewCookLogRoutes(h, cookLog, sess)
 	}
 }
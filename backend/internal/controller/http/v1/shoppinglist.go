@@ -0,0 +1,353 @@
+package v1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/Homyakadze14/RecipeSite/internal/common"
+	"github.com/Homyakadze14/RecipeSite/internal/entities"
+	"github.com/Homyakadze14/RecipeSite/internal/usecases"
+	"github.com/gin-gonic/gin"
+)
+
+var ErrShoppingListItemIDType = errors.New("shopping list item ID must be integer")
+
+type shoppingListRoutes struct {
+	u  *usecases.ShoppingListUseCase
+	su *usecases.SessionUseCase
+}
+
+func NewShoppingListRoutes(handler *gin.RouterGroup, u *usecases.ShoppingListUseCase, su *usecases.SessionUseCase) {
+	r := &shoppingListRoutes{u, su}
+
+	ur := handler.Group("/user/:login/shoppinglist")
+	{
+		ur.Use(su.Auth())
+		ur.GET("", r.get)
+		ur.DELETE("", r.clear)
+		ur.GET("/export", r.export)
+		ur.POST("/recipes", r.addRecipes)
+		ur.POST("/mealplan", r.addMealPlan)
+		ur.POST("/items", r.addItem)
+		ur.PUT("/items/:id", r.updateItem)
+		ur.DELETE("/items/:id", r.deleteItem)
+	}
+}
+
+// shoppingListError writes the response for an error of the shopping list use case.
+func (r *shoppingListRoutes) shoppingListError(c *gin.Context, err error) {
+	slog.Error(err.Error())
+	switch {
+	case errors.Is(err, usecases.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": usecases.ErrUserNotFound.Error()})
+	case errors.Is(err, usecases.ErrRecipeNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": usecases.ErrRecipeNotFound.Error()})
+	case errors.Is(err, usecases.ErrShoppingListItemNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": usecases.ErrShoppingListItemNotFound.Error()})
+	case errors.Is(err, common.ErrNoPermissions):
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.ErrNoPermissions.Error()})
+	case errors.Is(err, usecases.ErrMealPlanRange):
+		c.JSON(http.StatusBadRequest, gin.H{"error": usecases.ErrMealPlanRange.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+	}
+}
+
+// @Summary     Get shopping list
+// @Description Get the shopping list, items to buy go first
+// @ID          get shopping list
+// @Tags  	    shoppinglist
+// @Produce     json
+// @Success     200 {object} entities.ShoppingList
+// @Failure     400
+// @Failure     401
+// @Failure     404
+// @Failure     500
+// @Router      /user/{login}/shoppinglist [get]
+func (r *shoppingListRoutes) get(c *gin.Context) {
+	sess, err := r.su.SessionFromContext(c)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	list, err := r.u.Get(c.Request.Context(), c.Param("login"), sess.UserID)
+	if err != nil {
+		r.shoppingListError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+// @Summary     Export shopping list
+// @Description Export the shopping list as plain text or as a Markdown task list
+// @ID          export shopping list
+// @Tags  	    shoppinglist
+// @Param 		format query entities.ShoppingListExport false "format"
+// @Produce     plain
+// @Success     200 {string} string
+// @Failure     400
+// @Failure     401
+// @Failure     404
+// @Failure     500
+// @Router      /user/{login}/shoppinglist/export [get]
+func (r *shoppingListRoutes) export(c *gin.Context) {
+	var params entities.ShoppingListExport
+	if err := c.ShouldBindQuery(&params); err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.GetErrMessages(err).Error()})
+		return
+	}
+
+	sess, err := r.su.SessionFromContext(c)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	text, err := r.u.Export(c.Request.Context(), c.Param("login"), sess.UserID, &params)
+	if err != nil {
+		r.shoppingListError(c, err)
+		return
+	}
+
+	contentType, filename := "text/plain; charset=utf-8", "shopping-list.txt"
+	if params.Format == entities.ExportMarkdown {
+		contentType, filename = "text/markdown; charset=utf-8", "shopping-list.md"
+	}
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Data(http.StatusOK, contentType, []byte(text))
+}
+
+// @Summary     Add recipes to shopping list
+// @Description Put the ingredients of the recipes on the shopping list. Identical ingredients
+// @Description in compatible units are summed up with the items which aren't bought yet.
+// @ID          add recipes to shopping list
+// @Tags  	    shoppinglist
+// @Accept      json
+// @Param 		recipes body entities.AddShoppingListRecipes true "recipes"
+// @Produce     json
+// @Success     200 {object} entities.ShoppingList
+// @Failure     400
+// @Failure     401
+// @Failure     404
+// @Failure     500
+// @Router      /user/{login}/shoppinglist/recipes [post]
+func (r *shoppingListRoutes) addRecipes(c *gin.Context) {
+	var params entities.AddShoppingListRecipes
+	if err := c.ShouldBindJSON(&params); err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.GetErrMessages(err).Error()})
+		return
+	}
+
+	sess, err := r.su.SessionFromContext(c)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	list, err := r.u.AddRecipes(c.Request.Context(), c.Param("login"), sess.UserID, &params)
+	if err != nil {
+		r.shoppingListError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+// @Summary     Add meal plan to shopping list
+// @Description Put the ingredients of the meals planned from "from" to "to" inclusive on the
+// @Description shopping list, scaled to the planned servings
+// @ID          add meal plan to shopping list
+// @Tags  	    shoppinglist
+// @Accept      json
+// @Param 		range body entities.MealPlanRange true "range"
+// @Produce     json
+// @Success     200 {object} entities.ShoppingList
+// @Failure     400
+// @Failure     401
+// @Failure     404
+// @Failure     500
+// @Router      /user/{login}/shoppinglist/mealplan [post]
+func (r *shoppingListRoutes) addMealPlan(c *gin.Context) {
+	var params entities.MealPlanRange
+	if err := c.ShouldBindJSON(&params); err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.GetErrMessages(err).Error()})
+		return
+	}
+
+	sess, err := r.su.SessionFromContext(c)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	list, err := r.u.AddMealPlan(c.Request.Context(), c.Param("login"), sess.UserID, &params)
+	if err != nil {
+		r.shoppingListError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+// @Summary     Add item to shopping list
+// @Description Put an item which isn't from a recipe on the shopping list
+// @ID          add item to shopping list
+// @Tags  	    shoppinglist
+// @Accept      json
+// @Param 		item body entities.AddShoppingListItem true "item"
+// @Produce     json
+// @Success     200 {object} entities.ShoppingList
+// @Failure     400
+// @Failure     401
+// @Failure     404
+// @Failure     500
+// @Router      /user/{login}/shoppinglist/items [post]
+func (r *shoppingListRoutes) addItem(c *gin.Context) {
+	var params entities.AddShoppingListItem
+	if err := c.ShouldBindJSON(&params); err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.GetErrMessages(err).Error()})
+		return
+	}
+
+	sess, err := r.su.SessionFromContext(c)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	list, err := r.u.AddItem(c.Request.Context(), c.Param("login"), sess.UserID, &params)
+	if err != nil {
+		r.shoppingListError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+// @Summary     Update shopping list item
+// @Description Check the item off or change it
+// @ID          update shopping list item
+// @Tags  	    shoppinglist
+// @Accept      json
+// @Param 		item body entities.UpdateShoppingListItem true "item"
+// @Produce     json
+// @Success     200
+// @Failure     400
+// @Failure     401
+// @Failure     404
+// @Failure     500
+// @Router      /user/{login}/shoppinglist/items/{id} [put]
+func (r *shoppingListRoutes) updateItem(c *gin.Context) {
+	itemID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		slog.Error(ErrShoppingListItemIDType.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": ErrShoppingListItemIDType.Error()})
+		return
+	}
+
+	var params entities.UpdateShoppingListItem
+	if err := c.ShouldBindJSON(&params); err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.GetErrMessages(err).Error()})
+		return
+	}
+
+	sess, err := r.su.SessionFromContext(c)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	err = r.u.UpdateItem(c.Request.Context(), c.Param("login"), sess.UserID, itemID, &params)
+	if err != nil {
+		r.shoppingListError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "item updated"})
+}
+
+// @Summary     Delete shopping list item
+// @Description Remove the item from the shopping list
+// @ID          delete shopping list item
+// @Tags  	    shoppinglist
+// @Produce     json
+// @Success     200
+// @Failure     400
+// @Failure     401
+// @Failure     404
+// @Failure     500
+// @Router      /user/{login}/shoppinglist/items/{id} [delete]
+func (r *shoppingListRoutes) deleteItem(c *gin.Context) {
+	itemID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		slog.Error(ErrShoppingListItemIDType.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": ErrShoppingListItemIDType.Error()})
+		return
+	}
+
+	sess, err := r.su.SessionFromContext(c)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	err = r.u.DeleteItem(c.Request.Context(), c.Param("login"), sess.UserID, itemID)
+	if err != nil {
+		r.shoppingListError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "item deleted"})
+}
+
+// @Summary     Clear shopping list
+// @Description Remove all the items from the shopping list or only the checked ones
+// @ID          clear shopping list
+// @Tags  	    shoppinglist
+// @Param 		clear query entities.ClearShoppingList false "clear"
+// @Produce     json
+// @Success     200
+// @Failure     400
+// @Failure     401
+// @Failure     404
+// @Failure     500
+// @Router      /user/{login}/shoppinglist [delete]
+func (r *shoppingListRoutes) clear(c *gin.Context) {
+	var params entities.ClearShoppingList
+	if err := c.ShouldBindQuery(&params); err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.GetErrMessages(err).Error()})
+		return
+	}
+
+	sess, err := r.su.SessionFromContext(c)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	removed, err := r.u.Clear(c.Request.Context(), c.Param("login"), sess.UserID, &params)
+	if err != nil {
+		r.shoppingListError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"removed": removed})
+}
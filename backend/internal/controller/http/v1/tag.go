@@ -0,0 +1,82 @@
+package v1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/Homyakadze14/RecipeSite/internal/common"
+	"github.com/Homyakadze14/RecipeSite/internal/entities"
+	"github.com/Homyakadze14/RecipeSite/internal/usecases"
+	"github.com/gin-gonic/gin"
+)
+
+type tagRoutes struct {
+	u *usecases.TagUseCase
+}
+
+func NewTagRoutes(handler *gin.RouterGroup, u *usecases.TagUseCase) {
+	r := &tagRoutes{u}
+
+	h := handler.Group("/tags")
+	{
+		h.GET("", r.getAll)
+		h.GET("/:slug/recipes", r.getRecipes)
+	}
+}
+
+// @Summary     Get all tags
+// @Description Get all tags with counts of their recipes
+// @ID          get all tags
+// @Tags  	    tags
+// @Produce     json
+// @Success     200 {object} []entities.TagWithCount
+// @Failure     500
+// @Router      /tags [get]
+func (r *tagRoutes) getAll(c *gin.Context) {
+	tags, err := r.u.GetAll(c.Request.Context())
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"tags": tags})
+}
+
+// @Summary     Get recipes with tag
+// @Description Get the newest recipes with tag
+// @ID          get tag recipes
+// @Tags  	    tags
+// @Param 		page query entities.RecipePage false "page"
+// @Produce     json
+// @Success     200 {object} entities.RecipeList
+// @Failure     400
+// @Failure     404
+// @Failure     500
+// @Router      /tags/{slug}/recipes [get]
+func (r *tagRoutes) getRecipes(c *gin.Context) {
+	var page entities.RecipePage
+	if err := c.ShouldBindQuery(&page); err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.GetErrMessages(err).Error()})
+		return
+	}
+
+	recipes, err := r.u.GetRecipes(c.Request.Context(), c.Param("slug"), &page)
+	if err != nil {
+		slog.Error(err.Error())
+		if errors.Is(err, usecases.ErrTagNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": usecases.ErrTagNotFound.Error()})
+			return
+		}
+		if errors.Is(err, usecases.ErrBadCursor) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": usecases.ErrBadCursor.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, recipes)
+}
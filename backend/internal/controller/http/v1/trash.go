@@ -0,0 +1,212 @@
+package v1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/Homyakadze14/RecipeSite/internal/common"
+	"github.com/Homyakadze14/RecipeSite/internal/entities"
+	"github.com/Homyakadze14/RecipeSite/internal/usecases"
+	"github.com/gin-gonic/gin"
+)
+
+var ErrCommentIDType = errors.New("comment ID must be integer")
+
+type trashRoutes struct {
+	u  *usecases.TrashUseCase
+	su *usecases.SessionUseCase
+}
+
+func NewTrashRoutes(handler *gin.RouterGroup, u *usecases.TrashUseCase, su *usecases.SessionUseCase) {
+	r := &trashRoutes{u, su}
+
+	h := handler.Group("/auth")
+	{
+		h.POST("/restore", r.restoreUser)
+	}
+
+	ur := handler.Group("/user/:login/trash")
+	{
+		ur.Use(su.Auth())
+		ur.GET("", r.get)
+		ur.POST("/recipes/:id/restore", r.restoreRecipe)
+		ur.POST("/comments/:id/restore", r.restoreComment)
+	}
+}
+
+// @Summary     Get trash
+// @Description Get deleted recipes and comments of the user which may still be restored
+// @ID          get trash
+// @Tags  	    trash
+// @Produce     json
+// @Success     200 {object} entities.Trash
+// @Failure     400
+// @Failure     401
+// @Failure     404
+// @Failure     500
+// @Router      /user/{login}/trash [get]
+func (r *trashRoutes) get(c *gin.Context) {
+	sess, err := r.su.SessionFromContext(c)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	trash, err := r.u.Get(c.Request.Context(), c.Param("login"), sess.UserID)
+	if err != nil {
+		slog.Error(err.Error())
+		if errors.Is(err, usecases.ErrUserNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": usecases.ErrUserNotFound.Error()})
+			return
+		}
+		if errors.Is(err, common.ErrNoPermissions) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": common.ErrNoPermissions.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, trash)
+}
+
+// @Summary     Restore recipe
+// @Description Take the recipe out of the trash
+// @ID          restore recipe
+// @Tags  	    trash
+// @Produce     json
+// @Success     200
+// @Failure     400
+// @Failure     401
+// @Failure     404
+// @Failure     500
+// @Router      /user/{login}/trash/recipes/{id}/restore [post]
+func (r *trashRoutes) restoreRecipe(c *gin.Context) {
+	recipeID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		slog.Error(common.ErrRecipeIDType.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.ErrRecipeIDType.Error()})
+		return
+	}
+
+	sess, err := r.su.SessionFromContext(c)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	err = r.u.RestoreRecipe(c.Request.Context(), c.Param("login"), sess.UserID, recipeID)
+	if err != nil {
+		slog.Error(err.Error())
+		if errors.Is(err, usecases.ErrUserNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": usecases.ErrUserNotFound.Error()})
+			return
+		}
+		if errors.Is(err, usecases.ErrRecipeNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": usecases.ErrRecipeNotFound.Error()})
+			return
+		}
+		if errors.Is(err, common.ErrNoPermissions) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": common.ErrNoPermissions.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "recipe restored"})
+}
+
+// @Summary     Restore comment
+// @Description Take the comment out of the trash
+// @ID          restore comment
+// @Tags  	    trash
+// @Produce     json
+// @Success     200
+// @Failure     400
+// @Failure     401
+// @Failure     404
+// @Failure     500
+// @Router      /user/{login}/trash/comments/{id}/restore [post]
+func (r *trashRoutes) restoreComment(c *gin.Context) {
+	commentID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		slog.Error(ErrCommentIDType.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": ErrCommentIDType.Error()})
+		return
+	}
+
+	sess, err := r.su.SessionFromContext(c)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	err = r.u.RestoreComment(c.Request.Context(), c.Param("login"), sess.UserID, commentID)
+	if err != nil {
+		slog.Error(err.Error())
+		if errors.Is(err, usecases.ErrUserNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": usecases.ErrUserNotFound.Error()})
+			return
+		}
+		if errors.Is(err, usecases.ErrCommentNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": usecases.ErrCommentNotFound.Error()})
+			return
+		}
+		if errors.Is(err, common.ErrNoPermissions) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": common.ErrNoPermissions.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "comment restored"})
+}
+
+// @Summary     Restore user
+// @Description Bring back a deleted account with its recipes and comments and sign in
+// @ID          restore user
+// @Tags  	    auth
+// @Param 		user body entities.UserLogin  true  "User params"
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} entities.AuthUser
+// @Failure     400
+// @Failure     404
+// @Failure     500
+// @Router      /auth/restore [post]
+func (r *trashRoutes) restoreUser(c *gin.Context) {
+	var params entities.UserLogin
+	if err := c.ShouldBindJSON(&params); err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.GetErrMessages(err).Error()})
+		return
+	}
+
+	authInfo, err := r.u.RestoreUser(c.Request.Context(), &params)
+	if err != nil {
+		slog.Error(err.Error())
+		if errors.Is(err, usecases.ErrUserCredentials) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": usecases.ErrUserCredentials.Error()})
+			return
+		}
+		if errors.Is(err, usecases.ErrUserNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": usecases.ErrUserNotFound.Error()})
+			return
+		}
+		if errors.Is(err, usecases.ErrUserWrongPassword) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": usecases.ErrUserWrongPassword.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, authInfo)
+}
@@ -44,6 +44,7 @@ func NewUserRoutes(handler *gin.RouterGroup, u *usecases.UserUseCase, su *usecas
 		usr.Use(su.Auth())
 		usr.PUT("/:login", r.update)
 		usr.PUT("/:login/password", r.updatePassword)
+		usr.DELETE("/:login", r.delete)
 		usr.GET("/:login/icon", r.getDBIcon)
 	}
 
@@ -375,6 +376,51 @@ func (r *userRoutes) updatePassword(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"status": "user password updated"})
 }
 
+// @Summary     Delete user
+// @Description Move the user with all the recipes and comments to the trash.
+// @Description The account may be restored with POST /auth/restore until the trash is purged.
+// @ID          delete user
+// @Tags  	    user
+// @Produce     json
+// @Success     200
+// @Failure     400
+// @Failure     404
+// @Failure     401
+// @Failure     500
+// @Router      /user/{login} [delete]
+func (r *userRoutes) delete(c *gin.Context) {
+	login, ok := c.Params.Get("login")
+	if !ok {
+		slog.Error(common.ErrLoginProvided.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": common.ErrLoginProvided.Error()})
+		return
+	}
+
+	sess, err := r.su.SessionFromContext(c)
+	if err != nil {
+		slog.Error(err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	err = r.u.Delete(c.Request.Context(), login, sess.UserID)
+	if err != nil {
+		slog.Error(err.Error())
+		if errors.Is(err, usecases.ErrUserNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": usecases.ErrUserNotFound.Error()})
+			return
+		}
+		if errors.Is(err, common.ErrNoPermissions) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": common.ErrNoPermissions.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": common.ErrServerError.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "user deleted"})
+}
+
 // @Summary     Get user info
 // @Description Get user info
 // @ID          get user info
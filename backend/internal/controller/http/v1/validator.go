@@ -0,0 +1,34 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/Homyakadze14/RecipeSite/internal/entities"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// registerValidators adds the validation tags of the label taxonomy,
+// so the binding tags don't have to repeat it.
+func registerValidators() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		slog.Error("v1 - registerValidators - binding.Validator.Engine: not a validator.Validate")
+		return
+	}
+
+	taxonomy := map[string][]string{
+		"allergen": entities.Allergens,
+		"diet":     entities.Diets,
+	}
+	for tag, values := range taxonomy {
+		err := v.RegisterValidation(tag, func(fl validator.FieldLevel) bool {
+			return slices.Contains(values, fl.Field().String())
+		})
+		if err != nil {
+			slog.Error(fmt.Errorf("v1 - registerValidators - v.RegisterValidation: %w", err).Error())
+		}
+	}
+}
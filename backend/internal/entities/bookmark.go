@@ -0,0 +1,7 @@
+package entities
+
+type Bookmark struct {
+	ID       int
+	UserID   int
+	RecipeID int
+}
@@ -0,0 +1,75 @@
+package entities
+
+import (
+	"io"
+	"time"
+)
+
+type Collection struct {
+	ID           int       `json:"id"`
+	UserID       int       `json:"user_id"`
+	Title        string    `json:"title"`
+	About        string    `json:"about"`
+	CoverURL     string    `json:"cover_url"`
+	IsPublic     bool      `json:"is_public"`
+	RecipesCount int       `json:"recipes_count"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// IsVisibleTo tells if the user may see the collection. Private
+// collections are shown to their owner only.
+func (c *Collection) IsVisibleTo(userID int) bool {
+	return c.IsPublic || c.UserID == userID
+}
+
+type FullCollection struct {
+	Collection *Collection        `json:"collection"`
+	Recipes    []RecipeWithAuthor `json:"recipes"`
+}
+
+type CreateCollection struct {
+	Title    string        `json:"title" binding:"required,min=1,max=100" form:"title"`
+	About    string        `json:"about" binding:"max=1000" form:"about"`
+	IsPublic bool          `json:"is_public" form:"is_public"`
+	Cover    io.ReadSeeker `json:"-" form:"-"`
+}
+
+func (c *CreateCollection) ToCollection() *Collection {
+	return &Collection{
+		Title:    c.Title,
+		About:    c.About,
+		IsPublic: c.IsPublic,
+	}
+}
+
+type UpdateCollection struct {
+	Title    string        `json:"title" binding:"omitempty,min=1,max=100" form:"title"`
+	About    string        `json:"about" binding:"omitempty,max=1000" form:"about"`
+	IsPublic *bool         `json:"is_public" form:"is_public"`
+	Cover    io.ReadSeeker `json:"-" form:"-"`
+}
+
+func (u *UpdateCollection) UpdateValues(c *Collection) {
+	if u.Title != "" {
+		c.Title = u.Title
+	}
+	if u.About != "" {
+		c.About = u.About
+	}
+	if u.IsPublic != nil {
+		c.IsPublic = *u.IsPublic
+	}
+}
+
+// CollectionRecipe adds the recipe to a collection at the position,
+// counting from 1. Without a position the recipe goes to the end.
+type CollectionRecipe struct {
+	RecipeID int `json:"recipe_id" binding:"required"`
+	Position int `json:"position" binding:"min=0"`
+}
+
+// CollectionOrder lists all the recipes of a collection in the new order.
+type CollectionOrder struct {
+	RecipeIDs []int `json:"recipe_ids" binding:"required"`
+}
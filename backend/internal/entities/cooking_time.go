@@ -0,0 +1,74 @@
+package entities
+
+import (
+	"fmt"
+
+	"github.com/Homyakadze14/RecipeSite/internal/units"
+)
+
+const maxCookingMinutes = 7 * 24 * 60
+
+var ErrCookingTimeTooLong = fmt.Errorf("cooking time must not exceed %v minutes", maxCookingMinutes)
+
+// CookingTime is the cooking time sent by a client. Every field accepts
+// ISO-8601 durations (PT1H30M) as well as "1h 30m" like strings.
+// NeedTime is the total time, it is kept for old clients.
+type CookingTime struct {
+	NeedTime string `json:"need_time" binding:"omitempty,max=50" form:"need_time" example:"1h 30m"`
+	PrepTime string `json:"prep_time" binding:"omitempty,max=50" form:"prep_time" example:"PT15M"`
+	CookTime string `json:"cook_time" binding:"omitempty,max=50" form:"cook_time" example:"1h 15m"`
+
+	needMinutes int
+	prepMinutes int
+	cookMinutes int
+}
+
+// Parse turns the sent durations into minutes.
+func (t *CookingTime) Parse() error {
+	var err error
+	if t.needMinutes, err = parseCookingTime("need_time", t.NeedTime); err != nil {
+		return err
+	}
+	if t.prepMinutes, err = parseCookingTime("prep_time", t.PrepTime); err != nil {
+		return err
+	}
+	if t.cookMinutes, err = parseCookingTime("cook_time", t.CookTime); err != nil {
+		return err
+	}
+	return nil
+}
+
+func parseCookingTime(field, value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	minutes, err := units.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", field, err)
+	}
+	if minutes > maxCookingMinutes {
+		return 0, fmt.Errorf("%s: %w", field, ErrCookingTimeTooLong)
+	}
+	return minutes, nil
+}
+
+// apply sets the sent times to the recipe. Without cook time it is what is
+// left of the total time after preparation.
+func (t *CookingTime) apply(recipe *Recipe) {
+	if t.PrepTime != "" {
+		recipe.PrepMinutes = t.prepMinutes
+	}
+	if t.CookTime != "" {
+		recipe.CookMinutes = t.cookMinutes
+	} else if t.NeedTime != "" {
+		recipe.CookMinutes = max(t.needMinutes-recipe.PrepMinutes, 0)
+	}
+	recipe.SetTotalMinutes(recipe.PrepMinutes + recipe.CookMinutes)
+}
+
+// SetTotalMinutes sets the total time together with its legacy text form.
+func (r *Recipe) SetTotalMinutes(minutes int) {
+	r.TotalMinutes = minutes
+	r.NeedTime = units.FormatDuration(minutes)
+}
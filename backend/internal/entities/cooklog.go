@@ -0,0 +1,27 @@
+package entities
+
+import (
+	"io"
+	"time"
+)
+
+type CookLog struct {
+	ID       int    `json:"id"`
+	UserID   int    `json:"-"`
+	RecipeID int    `json:"recipe_id"`
+	CookedOn string `json:"cooked_on" example:"2025-01-06"`
+	Note     string `json:"note,omitempty" example:"Added more garlic"`
+	PhotoURL string `json:"photo_url,omitempty"`
+	// Author is set when the logs of a recipe are listed, Recipe when the
+	// logs of a user are.
+	Author    *Author           `json:"author,omitempty"`
+	Recipe    *RecipeWithAuthor `json:"recipe,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// CreateCookLog marks the recipe as cooked, today unless CookedOn is set.
+type CreateCookLog struct {
+	CookedOn string        `form:"cooked_on" binding:"omitempty,datetime=2006-01-02" example:"2025-01-06"`
+	Note     string        `form:"note" binding:"max=500" example:"Added more garlic"`
+	Photo    io.ReadSeeker `json:"-" form:"-"`
+}
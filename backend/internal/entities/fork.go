@@ -0,0 +1,31 @@
+package entities
+
+// RecipeAncestor is a recipe another recipe was forked from.
+type RecipeAncestor struct {
+	ID     int    `json:"id"`
+	Title  string `json:"title"`
+	Author string `json:"author_login"`
+}
+
+type RecipeForkMsg struct {
+	AuthorID    int
+	RecipeID    int
+	ForkID      int
+	ForkerLogin string
+}
+
+// Fork copies the recipe for the user as a draft. Photos are shared with
+// the original recipe, they are not uploaded again.
+func (r *Recipe) Fork(userID int) *Recipe {
+	fork := r.Clone()
+	fork.ID = 0
+	fork.UserID = userID
+	fork.ForkedFrom = &r.ID
+	fork.Status = StatusDraft
+	fork.PublishAt = nil
+	fork.PublishedAt = nil
+	fork.DeletedAt = nil
+	fork.Snippet = ""
+	fork.Cursor = ""
+	return fork
+}
@@ -0,0 +1,105 @@
+package entities
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type Ingredient struct {
+	Name     string  `json:"name" binding:"required,min=1,max=100" example:"flour"`
+	Quantity float64 `json:"quantity,omitempty" binding:"min=0" example:"200"`
+	Unit     string  `json:"unit,omitempty" binding:"max=20" example:"g"`
+	Note     string  `json:"note,omitempty" binding:"max=250" example:"sifted"`
+	// DisplayQuantity is a human friendly quantity (e.g. "1 1/2") set when
+	// the ingredient was recalculated for the reader. It is never saved.
+	DisplayQuantity string `json:"display_quantity,omitempty"`
+}
+
+const (
+	ingredientUnits = `kg|g|mg|ml|l|tsp|tbsp|cups?|oz|lb|pcs|кг|г|мг|мл|л|шт`
+	legacyTextLimit = 10000
+)
+
+var (
+	leadQuantityRe  = regexp.MustCompile(`(?i)^(\d+(?:[.,]\d+)?)\s*(` + ingredientUnits + `)?\.?\s+(.+)$`)
+	trailQuantityRe = regexp.MustCompile(`(?i)^(.+?)\s*[-–—:]\s*(\d+(?:[.,]\d+)?)\s*(` + ingredientUnits + `)?\.?$`)
+)
+
+// ParseIngredients splits legacy free-text ingredients (one per line) into
+// structured ones. It mirrors the parsing done by the add_ingredients migration.
+func ParseIngredients(text string) []Ingredient {
+	ingredients := make([]Ingredient, 0, 10)
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.Trim(line, " \t\r-*•")
+		if line == "" {
+			continue
+		}
+
+		ingredient := Ingredient{Name: line}
+		if m := leadQuantityRe.FindStringSubmatch(line); m != nil {
+			ingredient.Quantity = parseQuantity(m[1])
+			ingredient.Unit = strings.ToLower(m[2])
+			ingredient.Name = m[3]
+		} else if m := trailQuantityRe.FindStringSubmatch(line); m != nil {
+			ingredient.Name = m[1]
+			ingredient.Quantity = parseQuantity(m[2])
+			ingredient.Unit = strings.ToLower(m[3])
+		}
+
+		if len([]rune(ingredient.Name)) > 100 {
+			ingredient.Name = string([]rune(ingredient.Name)[:100])
+		}
+		ingredients = append(ingredients, ingredient)
+	}
+	return ingredients
+}
+
+func parseQuantity(s string) float64 {
+	q, err := strconv.ParseFloat(strings.Replace(s, ",", ".", 1), 64)
+	if err != nil {
+		return 0
+	}
+	return q
+}
+
+// FormatQuantity prints a quantity without trailing zeros.
+func FormatQuantity(q float64) string {
+	return strconv.FormatFloat(q, 'f', -1, 64)
+}
+
+func (i *Ingredient) String() string {
+	var s strings.Builder
+	quantity := i.DisplayQuantity
+	if quantity == "" && i.Quantity != 0 {
+		quantity = FormatQuantity(i.Quantity)
+	}
+	if quantity != "" {
+		s.WriteString(quantity)
+		s.WriteString(" ")
+		if i.Unit != "" {
+			s.WriteString(i.Unit)
+			s.WriteString(" ")
+		}
+	}
+	s.WriteString(i.Name)
+	if i.Note != "" {
+		s.WriteString(fmt.Sprintf(" (%s)", i.Note))
+	}
+	return s.String()
+}
+
+// FormatIngredients renders structured ingredients back to the legacy
+// one-per-line text kept in Recipe.Ingridients for old clients.
+func FormatIngredients(ingredients []Ingredient) string {
+	lines := make([]string, 0, len(ingredients))
+	for _, ingredient := range ingredients {
+		lines = append(lines, ingredient.String())
+	}
+	text := []rune(strings.Join(lines, "\n"))
+	if len(text) > legacyTextLimit {
+		text = text[:legacyTextLimit]
+	}
+	return string(text)
+}
@@ -0,0 +1,17 @@
+package entities
+
+// Allergens and Diets are the fixed taxonomy of recipe labels. Label fields
+// are validated against them with the allergen and diet binding tags.
+var (
+	Allergens = []string{"gluten", "dairy", "eggs", "nuts", "peanuts", "soy", "fish", "shellfish", "sesame", "celery", "mustard"}
+	Diets     = []string{"vegan", "vegetarian", "pescatarian", "halal", "kosher", "gluten-free", "lactose-free"}
+)
+
+type Labels struct {
+	Allergens []string `json:"allergens"`
+	Diets     []string `json:"diets"`
+}
+
+type LabelsSuggestion struct {
+	Ingredients []Ingredient `json:"ingredients" binding:"required,min=1,max=50,dive"`
+}
@@ -0,0 +1,107 @@
+package entities
+
+import "time"
+
+// DateLayout is the layout of dates in the meal plan.
+const DateLayout = "2006-01-02"
+
+const (
+	MealBreakfast = "breakfast"
+	MealLunch     = "lunch"
+	MealDinner    = "dinner"
+)
+
+type MealPlanEntry struct {
+	ID       int               `json:"id"`
+	Date     string            `json:"date" example:"2025-01-06"`
+	Slot     string            `json:"slot" enums:"breakfast,lunch,dinner"`
+	Servings int               `json:"servings"`
+	Recipe   *RecipeWithAuthor `json:"recipe"`
+}
+
+// AddMealPlanEntry puts the recipe into the slot on the date. Without servings
+// the servings of the recipe are planned.
+type AddMealPlanEntry struct {
+	Date     string `json:"date" binding:"required,datetime=2006-01-02" example:"2025-01-06"`
+	Slot     string `json:"slot" binding:"required,oneof=breakfast lunch dinner" enums:"breakfast,lunch,dinner"`
+	RecipeID int    `json:"recipe_id" binding:"required"`
+	Servings int    `json:"servings" binding:"omitempty,min=1,max=100"`
+}
+
+// UpdateMealPlanEntry moves the entry to another slot or date or changes its servings.
+type UpdateMealPlanEntry struct {
+	Date     string `json:"date" binding:"omitempty,datetime=2006-01-02" example:"2025-01-07"`
+	Slot     string `json:"slot" binding:"omitempty,oneof=breakfast lunch dinner" enums:"breakfast,lunch,dinner"`
+	Servings int    `json:"servings" binding:"omitempty,min=1,max=100"`
+}
+
+func (u *UpdateMealPlanEntry) UpdateValues(entry *MealPlanEntry) {
+	if u.Date != "" {
+		entry.Date = u.Date
+	}
+	if u.Slot != "" {
+		entry.Slot = u.Slot
+	}
+	if u.Servings != 0 {
+		entry.Servings = u.Servings
+	}
+}
+
+type MealPlanRange struct {
+	From string `json:"from" form:"from" binding:"required,datetime=2006-01-02" example:"2025-01-06"`
+	To   string `json:"to" form:"to" binding:"required,datetime=2006-01-02" example:"2025-01-12"`
+}
+
+// MealPlanCopy copies the week starting at From to the week starting at To.
+type MealPlanCopy struct {
+	From string `json:"from" binding:"required,datetime=2006-01-02" example:"2025-01-06"`
+	To   string `json:"to" binding:"required,datetime=2006-01-02" example:"2025-01-13"`
+}
+
+type MealPlanDay struct {
+	Date      string          `json:"date" example:"2025-01-06"`
+	Breakfast []MealPlanEntry `json:"breakfast"`
+	Lunch     []MealPlanEntry `json:"lunch"`
+	Dinner    []MealPlanEntry `json:"dinner"`
+}
+
+type MealPlan struct {
+	From string        `json:"from" example:"2025-01-06"`
+	To   string        `json:"to" example:"2025-01-12"`
+	Days []MealPlanDay `json:"days"`
+}
+
+// NewMealPlan lays the entries out by days from from to to inclusive,
+// days without meals are kept so the plan can be shown as a calendar.
+func NewMealPlan(from, to time.Time, entries []MealPlanEntry) *MealPlan {
+	plan := &MealPlan{From: from.Format(DateLayout), To: to.Format(DateLayout)}
+
+	days := make(map[string]*MealPlanDay)
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		plan.Days = append(plan.Days, MealPlanDay{
+			Date:      date.Format(DateLayout),
+			Breakfast: []MealPlanEntry{},
+			Lunch:     []MealPlanEntry{},
+			Dinner:    []MealPlanEntry{},
+		})
+	}
+	for i := range plan.Days {
+		days[plan.Days[i].Date] = &plan.Days[i]
+	}
+
+	for _, entry := range entries {
+		day, ok := days[entry.Date]
+		if !ok {
+			continue
+		}
+		switch entry.Slot {
+		case MealBreakfast:
+			day.Breakfast = append(day.Breakfast, entry)
+		case MealLunch:
+			day.Lunch = append(day.Lunch, entry)
+		case MealDinner:
+			day.Dinner = append(day.Dinner, entry)
+		}
+	}
+	return plan
+}
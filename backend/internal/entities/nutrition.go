@@ -0,0 +1,25 @@
+package entities
+
+// Food is an entry of the nutrition reference. Facts are given per 100 g.
+type Food struct {
+	Name        string
+	Keywords    []string
+	Facts       NutritionFacts
+	PieceWeight float64
+}
+
+type NutritionFacts struct {
+	Calories float64 `json:"calories"`
+	Protein  float64 `json:"protein"`
+	Fat      float64 `json:"fat"`
+	Carbs    float64 `json:"carbs"`
+}
+
+type Nutrition struct {
+	Total      NutritionFacts `json:"total"`
+	PerServing NutritionFacts `json:"per_serving"`
+	// MatchedIngredients is the count of ingredients which were found in the
+	// nutrition reference, the rest aren't counted.
+	MatchedIngredients int `json:"matched_ingredients"`
+	Ingredients        int `json:"ingredients"`
+}
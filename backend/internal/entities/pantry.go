@@ -0,0 +1,39 @@
+package entities
+
+type PantryItem struct {
+	ID   int    `json:"id"`
+	Name string `json:"name" example:"eggs"`
+}
+
+type AddPantryItems struct {
+	Names []string `json:"names" binding:"required,min=1,max=50,dive,min=1,max=100" example:"eggs,flour"`
+}
+
+// IngredientSearch looks for recipes which can be cooked from the
+// ingredients at hand.
+type IngredientSearch struct {
+	// Ingredients are searched together with the pantry of the signed in user.
+	Ingredients []string `json:"ingredients" binding:"omitempty,max=50,dive,min=1,max=100" example:"eggs,milk"`
+	// IgnorePantry searches by Ingredients only.
+	IgnorePantry bool `json:"ignore_pantry"`
+	// MaxMissing drops recipes which need more ingredients than that to be bought.
+	MaxMissing *int `json:"max_missing" binding:"omitempty,min=0" example:"2"`
+	Limit      int  `json:"limit" binding:"omitempty,min=1,max=100" example:"20"`
+	Offset     int  `json:"offset" binding:"min=0" example:"0"`
+	// Units converts the ingredients and temperatures of the found recipes.
+	Units    string `json:"units" binding:"omitempty,oneof=metric imperial" enums:"metric,imperial"`
+	ViewerID int    `json:"-"`
+}
+
+type RecipeMatch struct {
+	Recipe *RecipeWithAuthor `json:"recipe"`
+	// Matched is the count of the ingredients of the recipe which are at hand.
+	Matched int `json:"matched"`
+	Total   int `json:"total"`
+	// Missing are the ingredients which have to be bought.
+	Missing []string `json:"missing"`
+}
+
+type RecipeMatchList struct {
+	Recipes []RecipeMatch `json:"recipes"`
+}
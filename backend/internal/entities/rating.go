@@ -0,0 +1,61 @@
+package entities
+
+import (
+	"math"
+	"time"
+)
+
+const MaxRating = 5
+
+type Rating struct {
+	ID        int       `json:"id"`
+	UserID    int       `json:"-"`
+	RecipeID  int       `json:"-"`
+	Author    *Author   `json:"author"`
+	Rating    int       `json:"rating" example:"5"`
+	Review    string    `json:"review,omitempty" example:"Cooked it twice already"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// Cursor points right after the rating in the list it was fetched for.
+	Cursor string `json:"-"`
+}
+
+// RatingPage selects a page of the ratings of a recipe. Cursor is the
+// next_cursor returned with the previous page.
+type RatingPage struct {
+	Cursor string `form:"cursor"`
+	Limit  int    `form:"limit,default=20" binding:"min=1,max=100"`
+}
+
+type RatingList struct {
+	Ratings    []Rating `json:"ratings"`
+	NextCursor string   `json:"next_cursor,omitempty"`
+}
+
+type RateRecipe struct {
+	Rating int    `json:"rating" binding:"required,min=1,max=5" example:"5"`
+	Review string `json:"review" binding:"max=2000" example:"Cooked it twice already"`
+}
+
+type RatingSummary struct {
+	Average float64 `json:"average" example:"4.5"`
+	Count   int     `json:"count" example:"12"`
+	// Distribution holds the counts of ratings from one star to five stars.
+	Distribution []int `json:"distribution" example:"0,0,1,4,7"`
+}
+
+// NewRatingSummary counts the average from the counts of ratings by stars.
+func NewRatingSummary(counts map[int]int) *RatingSummary {
+	summary := &RatingSummary{Distribution: make([]int, MaxRating)}
+
+	sum := 0
+	for stars := 1; stars <= MaxRating; stars++ {
+		summary.Distribution[stars-1] = counts[stars]
+		summary.Count += counts[stars]
+		sum += stars * counts[stars]
+	}
+	if summary.Count != 0 {
+		summary.Average = math.Round(float64(sum)/float64(summary.Count)*100) / 100
+	}
+	return summary
+}
@@ -2,36 +2,100 @@ package entities
 
 import (
 	"io"
+	"slices"
 	"time"
 )
 
 type Recipe struct {
-	ID           int       `json:"id"`
-	UserID       int       `json:"creator_user_id"`
-	Title        string    `json:"title" binding:"required,min=3,max=200"`
-	About        string    `json:"about" binding:"required,max=10000"`
-	Complexitiy  int       `json:"complexity" binding:"required,min=1,max=3"  enums:"1,2,3"`
-	NeedTime     string    `json:"need_time" binding:"required"`
-	Ingridients  string    `json:"ingridients" binding:"required,max=10000"`
-	Instructions string    `json:"instructions" binding:"required,max=10000"`
-	PhotosUrls   string    `json:"photos_urls"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ID           int          `json:"id"`
+	UserID       int          `json:"creator_user_id"`
+	Title        string       `json:"title" binding:"required,min=3,max=200"`
+	About        string       `json:"about" binding:"required,max=10000"`
+	Complexitiy  int          `json:"complexity" binding:"required,min=1,max=3"  enums:"1,2,3"`
+	PrepMinutes  int          `json:"prep_minutes"`
+	CookMinutes  int          `json:"cook_minutes"`
+	TotalMinutes int          `json:"total_minutes"`
+	NeedTime     string       `json:"need_time" example:"1h 30m"`
+	Servings     int          `json:"servings"`
+	Ingridients  string       `json:"ingridients" binding:"required,max=10000"`
+	Ingredients  []Ingredient `json:"ingredients,omitempty"`
+	Instructions string       `json:"instructions" binding:"required,max=10000"`
+	Steps        []RecipeStep `json:"steps,omitempty"`
+	Tags         []Tag        `json:"tags,omitempty"`
+	Allergens    []string     `json:"allergens"`
+	Diets        []string     `json:"diets"`
+	PhotosUrls   string       `json:"photos_urls"`
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+	Status       string       `json:"status" enums:"draft,published,archived,scheduled"`
+	PublishAt    *time.Time   `json:"publish_at,omitempty"`
+	PublishedAt  *time.Time   `json:"published_at,omitempty"`
+	Snippet      string       `json:"snippet,omitempty"`
+	DeletedAt    *time.Time   `json:"deleted_at,omitempty"`
+	ForkedFrom   *int         `json:"forked_from,omitempty"`
+	// Cursor points right after the recipe in the list it was fetched for.
+	Cursor string `json:"-"`
+}
+
+func (r *Recipe) ToRecipeWithAuthor() *RecipeWithAuthor {
+	return &RecipeWithAuthor{
+		ID:           r.ID,
+		UserID:       r.UserID,
+		Title:        r.Title,
+		About:        r.About,
+		Complexitiy:  r.Complexitiy,
+		PrepMinutes:  r.PrepMinutes,
+		CookMinutes:  r.CookMinutes,
+		TotalMinutes: r.TotalMinutes,
+		NeedTime:     r.NeedTime,
+		Servings:     r.Servings,
+		Ingridients:  r.Ingridients,
+		Ingredients:  r.Ingredients,
+		Instructions: r.Instructions,
+		Steps:        r.Steps,
+		Tags:         r.Tags,
+		Allergens:    r.Allergens,
+		Diets:        r.Diets,
+		PhotosUrls:   r.PhotosUrls,
+		CreatedAt:    r.CreatedAt,
+		UpdatedAt:    r.UpdatedAt,
+		Status:       r.Status,
+		PublishAt:    r.PublishAt,
+		PublishedAt:  r.PublishedAt,
+		Snippet:      r.Snippet,
+		ForkedFrom:   r.ForkedFrom,
+		Cursor:       r.Cursor,
+	}
 }
 
 type RecipeWithAuthor struct {
-	ID           int       `json:"id"`
-	UserID       int       `json:"creator_user_id"`
-	Title        string    `json:"title" binding:"required,min=3,max=50"`
-	About        string    `json:"about" binding:"required,max=10000"`
-	Complexitiy  int       `json:"complexity" binding:"required,min=1,max=3"  enums:"1,2,3"`
-	NeedTime     string    `json:"need_time" binding:"required"`
-	Ingridients  string    `json:"ingridients" binding:"required,max=10000"`
-	Instructions string    `json:"instructions" binding:"required,max=10000"`
-	PhotosUrls   string    `json:"photos_urls"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
-	Author       *Author   `json:"author"`
+	ID           int          `json:"id"`
+	UserID       int          `json:"creator_user_id"`
+	Title        string       `json:"title" binding:"required,min=3,max=50"`
+	About        string       `json:"about" binding:"required,max=10000"`
+	Complexitiy  int          `json:"complexity" binding:"required,min=1,max=3"  enums:"1,2,3"`
+	PrepMinutes  int          `json:"prep_minutes"`
+	CookMinutes  int          `json:"cook_minutes"`
+	TotalMinutes int          `json:"total_minutes"`
+	NeedTime     string       `json:"need_time" example:"1h 30m"`
+	Servings     int          `json:"servings"`
+	Ingridients  string       `json:"ingridients" binding:"required,max=10000"`
+	Ingredients  []Ingredient `json:"ingredients,omitempty"`
+	Instructions string       `json:"instructions" binding:"required,max=10000"`
+	Steps        []RecipeStep `json:"steps,omitempty"`
+	Tags         []Tag        `json:"tags,omitempty"`
+	Allergens    []string     `json:"allergens"`
+	Diets        []string     `json:"diets"`
+	PhotosUrls   string       `json:"photos_urls"`
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+	Status       string       `json:"status" enums:"draft,published,archived,scheduled"`
+	PublishAt    *time.Time   `json:"publish_at,omitempty"`
+	PublishedAt  *time.Time   `json:"published_at,omitempty"`
+	Snippet      string       `json:"snippet,omitempty"`
+	ForkedFrom   *int         `json:"forked_from,omitempty"`
+	Author       *Author      `json:"author"`
+	Cursor       string       `json:"-"`
 }
 
 type GetRecipeAuthor struct {
@@ -39,13 +103,34 @@ type GetRecipeAuthor struct {
 }
 
 type CreateRecipe struct {
-	Title        string          `json:"title" binding:"required,min=3,max=50"  form:"title"`
-	About        string          `json:"about" binding:"required,max=10000"  form:"about"`
-	Complexitiy  int             `json:"complexity" binding:"required,min=1,max=3"  enums:"1,2,3" form:"complexity"`
-	NeedTime     string          `json:"need_time" binding:"required"  form:"need_time"`
-	Ingridients  string          `json:"ingridients" binding:"required,max=10000"  form:"ingridients"`
-	Instructions string          `json:"instructions" binding:"required,max=10000" form:"instructions"`
-	Photos       []io.ReadSeeker `json:"-"`
+	Title       string `json:"title" binding:"required,min=3,max=50"  form:"title"`
+	About       string `json:"about" binding:"required,max=10000"  form:"about"`
+	Complexitiy int    `json:"complexity" binding:"required,min=1,max=3"  enums:"1,2,3" form:"complexity"`
+	CookingTime
+	Servings       int                   `json:"servings" binding:"omitempty,min=1,max=100" form:"servings" default:"1"`
+	Ingridients    string                `json:"ingridients" binding:"omitempty,max=10000"  form:"ingridients"`
+	RawIngredients string                `json:"ingredients" form:"ingredients" example:"[{\"name\":\"flour\",\"quantity\":200,\"unit\":\"g\"}]"`
+	Ingredients    []Ingredient          `json:"-" form:"-"`
+	Instructions   string                `json:"instructions" binding:"omitempty,max=10000" form:"instructions"`
+	RawSteps       string                `json:"steps" form:"steps" example:"[{\"text\":\"Mix the flour with eggs\",\"duration\":15}]"`
+	Steps          []RecipeStep          `json:"-" form:"-"`
+	StepPhotos     map[int]io.ReadSeeker `json:"-" form:"-"`
+	Tags           []string              `json:"tags" form:"tags" binding:"omitempty,max=10,dive,min=1,max=50" example:"breakfast"`
+	Allergens      []string              `json:"allergens" form:"allergens" binding:"omitempty,dive,allergen" example:"gluten"`
+	Diets          []string              `json:"diets" form:"diets" binding:"omitempty,dive,diet" example:"vegetarian"`
+	// Status is published unless the author saves a draft or schedules the recipe.
+	Status    string          `json:"status" form:"status" binding:"omitempty,oneof=draft published scheduled" enums:"draft,published,scheduled" default:"published"`
+	PublishAt *time.Time      `json:"publish_at" form:"publish_at" time_format:"2006-01-02T15:04:05Z07:00" example:"2025-01-01T10:00:00Z"`
+	Photos    []io.ReadSeeker `json:"-"`
+}
+
+// StatusChange is the status the recipe is created with.
+func (r *CreateRecipe) StatusChange() *RecipeStatusChange {
+	status := r.Status
+	if status == "" {
+		status = StatusPublished
+	}
+	return &RecipeStatusChange{Status: status, PublishAt: r.PublishAt}
 }
 
 func (r *CreateRecipe) HavePhotos() bool {
@@ -53,24 +138,87 @@ func (r *CreateRecipe) HavePhotos() bool {
 }
 
 func (r *CreateRecipe) ToRecipe() *Recipe {
-	return &Recipe{
+	recipe := &Recipe{
 		Title:        r.Title,
 		About:        r.About,
 		Complexitiy:  r.Complexitiy,
-		NeedTime:     r.NeedTime,
+		Servings:     r.Servings,
 		Ingridients:  r.Ingridients,
+		Ingredients:  r.Ingredients,
 		Instructions: r.Instructions,
+		Steps:        r.Steps,
+		Tags:         tagsFromSlugs(r.Tags),
+		Allergens:    r.Allergens,
+		Diets:        r.Diets,
+	}
+	if recipe.Servings == 0 {
+		recipe.Servings = 1
+	}
+	// Step photos are uploaded with the request, urls sent by the client
+	// would point at files of someone else.
+	for i := range recipe.Steps {
+		recipe.Steps[i].PhotoURL = ""
+	}
+	r.CookingTime.apply(recipe)
+	recipe.syncIngredients()
+	recipe.syncSteps()
+	return recipe
+}
+
+// syncIngredients keeps the structured ingredients and the legacy text in step:
+// the structured list wins, the text is parsed only when nothing else was sent.
+// DisplayQuantity is set on read only, so whatever the client sent is dropped.
+func (r *Recipe) syncIngredients() {
+	if len(r.Ingredients) != 0 {
+		for i := range r.Ingredients {
+			r.Ingredients[i].DisplayQuantity = ""
+		}
+		r.Ingridients = FormatIngredients(r.Ingredients)
+		return
 	}
+	r.Ingredients = ParseIngredients(r.Ingridients)
+}
+
+// syncSteps does the same for steps and the legacy instructions text.
+func (r *Recipe) syncSteps() {
+	if len(r.Steps) != 0 {
+		r.Instructions = FormatSteps(r.Steps)
+		return
+	}
+	r.Steps = ParseSteps(r.Instructions)
+}
+
+// StepPhotosUrls returns urls of all step photos of the recipe.
+func (r *Recipe) StepPhotosUrls() []string {
+	urls := make([]string, 0, len(r.Steps))
+	for _, step := range r.Steps {
+		if step.PhotoURL != "" {
+			urls = append(urls, step.PhotoURL)
+		}
+	}
+	return urls
 }
 
 type UpdateRecipe struct {
-	Title        string          `json:"title" binding:"omitempty,min=3,max=50"  form:"title"`
-	About        string          `json:"about" binding:"omitempty,max=10000"  form:"about"`
-	Complexitiy  int             `json:"complexity" binding:"omitempty,min=1,max=3" enums:"1,2,3" form:"complexity"`
-	NeedTime     string          `json:"need_time" binding:"omitempty"  form:"need_time"`
-	Ingridients  string          `json:"ingridients" binding:"omitempty,max=10000"  form:"ingridients"`
-	Instructions string          `json:"instructions" binding:"required,max=10000" form:"instructions"`
-	Photos       []io.ReadSeeker `json:"-"`
+	Title       string `json:"title" binding:"omitempty,min=3,max=50"  form:"title"`
+	About       string `json:"about" binding:"omitempty,max=10000"  form:"about"`
+	Complexitiy int    `json:"complexity" binding:"omitempty,min=1,max=3" enums:"1,2,3" form:"complexity"`
+	CookingTime
+	Servings       int                   `json:"servings" binding:"omitempty,min=1,max=100" form:"servings"`
+	Ingridients    string                `json:"ingridients" binding:"omitempty,max=10000"  form:"ingridients"`
+	RawIngredients string                `json:"ingredients" form:"ingredients" example:"[{\"name\":\"flour\",\"quantity\":200,\"unit\":\"g\"}]"`
+	Ingredients    []Ingredient          `json:"-" form:"-"`
+	Instructions   string                `json:"instructions" binding:"omitempty,max=10000" form:"instructions"`
+	RawSteps       string                `json:"steps" form:"steps" example:"[{\"text\":\"Mix the flour with eggs\",\"duration\":15}]"`
+	Steps          []RecipeStep          `json:"-" form:"-"`
+	StepPhotos     map[int]io.ReadSeeker `json:"-" form:"-"`
+	Tags           []string              `json:"tags" form:"tags" binding:"omitempty,max=10,dive,min=1,max=50" example:"breakfast"`
+	Allergens      []string              `json:"allergens" form:"allergens" binding:"omitempty,dive,allergen" example:"gluten"`
+	Diets          []string              `json:"diets" form:"diets" binding:"omitempty,dive,diet" example:"vegetarian"`
+	Photos         []io.ReadSeeker       `json:"-"`
+	// replaceLabels sets the tags, allergens and diets even when they are
+	// empty, so that a restored revision clears the current ones.
+	replaceLabels bool
 }
 
 func (r *UpdateRecipe) HavePhotos() bool {
@@ -87,15 +235,48 @@ func (r *UpdateRecipe) UpdateValues(recipe *Recipe) {
 	if r.About != "" {
 		recipe.About = r.About
 	}
-	if r.NeedTime != "" {
-		recipe.NeedTime = r.NeedTime
+	r.CookingTime.apply(recipe)
+	if r.Servings != 0 {
+		recipe.Servings = r.Servings
 	}
-	if r.Ingridients != "" {
+	if len(r.Ingredients) != 0 {
+		recipe.Ingredients = r.Ingredients
+		recipe.syncIngredients()
+	} else if r.Ingridients != "" {
 		recipe.Ingridients = r.Ingridients
+		recipe.Ingredients = nil
+		recipe.syncIngredients()
 	}
-	if r.Instructions != "" {
+	if len(r.Steps) != 0 {
+		// Steps may keep a photo only if it already belongs to this recipe.
+		known := recipe.StepPhotosUrls()
+		for i := range r.Steps {
+			if !slices.Contains(known, r.Steps[i].PhotoURL) {
+				r.Steps[i].PhotoURL = ""
+			}
+		}
+		recipe.Steps = r.Steps
+		recipe.syncSteps()
+	} else if r.Instructions != "" {
 		recipe.Instructions = r.Instructions
+		recipe.Steps = nil
+		recipe.syncSteps()
+	}
+	if len(r.Tags) != 0 || r.replaceLabels {
+		recipe.Tags = tagsFromSlugs(r.Tags)
+	}
+	if len(r.Allergens) != 0 || r.replaceLabels {
+		recipe.Allergens = r.Allergens
 	}
+	if len(r.Diets) != 0 || r.replaceLabels {
+		recipe.Diets = r.Diets
+	}
+}
+
+// RecipeViewParams changes how a single recipe is presented.
+type RecipeViewParams struct {
+	Servings int    `form:"servings" binding:"omitempty,min=1,max=100"`
+	Units    string `form:"units" binding:"omitempty,oneof=metric imperial"`
 }
 
 type RecipeInfo struct {
@@ -103,16 +284,65 @@ type RecipeInfo struct {
 }
 
 type FullRecipe struct {
-	Recipe     *RecipeWithAuthor `json:"recipe"`
-	LikesCount int               `json:"likes_count"`
-	IsLiked    bool              `json:"is_liked"`
-	Comments   []Comment         `json:"comments"`
+	Recipe       *RecipeWithAuthor `json:"recipe"`
+	LikesCount   int               `json:"likes_count"`
+	IsLiked      bool              `json:"is_liked"`
+	IsBookmarked bool              `json:"is_bookmarked"`
+	Comments     []Comment         `json:"comments"`
+	Nutrition    *Nutrition        `json:"nutrition,omitempty"`
+	ForksCount   int               `json:"forks_count"`
+	Rating       *RatingSummary    `json:"rating"`
+	// UserRating is the rating the viewer gave to the recipe, zero if none.
+	UserRating  int `json:"user_rating"`
+	CookedCount int `json:"cooked_count"`
+	// CookPhotos are the latest photos users took of the cooked recipe.
+	CookPhotos []CookLog `json:"cook_photos"`
+	// Lineage lists the recipes this one was forked from, the closest first.
+	Lineage []RecipeAncestor `json:"lineage,omitempty"`
+}
+
+// RecipePage selects a page of a recipe list. Cursor is the next_cursor
+// returned with the previous page.
+type RecipePage struct {
+	Cursor string `form:"cursor"`
+	Limit  int    `form:"limit,default=20" binding:"min=1,max=100"`
+	Units  string `form:"units" binding:"omitempty,oneof=metric imperial"`
+}
+
+type RecipeList struct {
+	Recipes    []RecipeWithAuthor `json:"recipes"`
+	NextCursor string             `json:"next_cursor,omitempty"`
+	// Total is the count of all recipes in the list, not only on this page.
+	Total int `json:"-"`
 }
 
 type RecipeFilter struct {
 	Limit      int    `json:"limit" example:"25"`
 	Offset     int    `json:"offset" example:"0"`
+	Cursor     string `json:"cursor"`
 	Query      string `json:"query" example:"tasty food"`
-	OrderField string `json:"order_field" example:"title"  enums:"title,about,ingridients,emtpy"`
+	OrderField string `json:"order_field" example:"title"  enums:"title,complexitiy,updated_at,need_time,relevance,rating,emtpy"`
 	OrderBy    int    `json:"order_by" binding:"min=-1,max=1"  enums:"-1,0,1"`
+
+	ComplexityMin int `json:"complexity_min" binding:"omitempty,min=1,max=3" enums:"1,2,3"`
+	ComplexityMax int `json:"complexity_max" binding:"omitempty,min=1,max=3" enums:"1,2,3"`
+	// MaxCookingTime is measured in minutes.
+	MaxCookingTime     int        `json:"max_cooking_time" binding:"omitempty,min=1" example:"60"`
+	Author             string     `json:"author" binding:"omitempty,max=20" example:"chef"`
+	IncludeIngredients []string   `json:"include_ingredients" binding:"omitempty,max=20,dive,min=1,max=100" example:"flour,eggs"`
+	ExcludeIngredients []string   `json:"exclude_ingredients" binding:"omitempty,max=20,dive,min=1,max=100" example:"nuts"`
+	CreatedAfter       *time.Time `json:"created_after" example:"2024-01-01T00:00:00Z"`
+	CreatedBefore      *time.Time `json:"created_before" example:"2025-01-01T00:00:00Z"`
+	MinLikes           int        `json:"min_likes" binding:"min=0" example:"10"`
+	// Tags are slugs, a recipe must have all of them.
+	Tags []string `json:"tags" binding:"omitempty,max=10,dive,min=1,max=50" example:"breakfast"`
+	// ExcludeAllergens drops recipes labeled with any of the allergens,
+	// Diet keeps recipes labeled with all of the diets.
+	ExcludeAllergens []string `json:"exclude_allergens" binding:"omitempty,dive,allergen" example:"nuts"`
+	Diet             []string `json:"diet" binding:"omitempty,dive,diet" example:"vegan"`
+	// Status other than published lists recipes of the viewer only.
+	Status string `json:"status" binding:"omitempty,oneof=draft published archived scheduled" enums:"draft,published,archived,scheduled" example:"draft"`
+	// Units converts the ingredients and temperatures of the found recipes.
+	Units    string `json:"units" binding:"omitempty,oneof=metric imperial" enums:"metric,imperial"`
+	ViewerID int    `json:"-"`
 }
@@ -0,0 +1,81 @@
+package entities
+
+import (
+	"errors"
+	"slices"
+	"time"
+)
+
+// A recipe is seen by everyone only when it is published. Drafts,
+// archived and scheduled recipes are seen by their author only.
+const (
+	StatusDraft     = "draft"
+	StatusPublished = "published"
+	StatusArchived  = "archived"
+	StatusScheduled = "scheduled"
+)
+
+var (
+	ErrPublishAtRequired = errors.New("publish_at must be provided for a scheduled recipe")
+	ErrPublishAtPast     = errors.New("publish_at must be in the future")
+	ErrStatusTransition  = errors.New("recipe can't be moved to this status")
+)
+
+type RecipeStatusChange struct {
+	Status    string     `json:"status" binding:"required,oneof=draft published archived scheduled" example:"published"`
+	PublishAt *time.Time `json:"publish_at" example:"2025-01-01T10:00:00Z"`
+}
+
+// IsBadStatusChange tells if the error was returned by Recipe.ChangeStatus.
+func IsBadStatusChange(err error) bool {
+	return errors.Is(err, ErrPublishAtRequired) || errors.Is(err, ErrPublishAtPast) || errors.Is(err, ErrStatusTransition)
+}
+
+// statusTransitions are the statuses a recipe may be moved to from each status.
+var statusTransitions = map[string][]string{
+	StatusDraft:     {StatusPublished, StatusScheduled},
+	StatusScheduled: {StatusDraft, StatusPublished, StatusScheduled},
+	StatusPublished: {StatusDraft, StatusArchived},
+	StatusArchived:  {StatusDraft, StatusPublished},
+}
+
+// IsVisibleTo tells if the user may see the recipe.
+func (r *Recipe) IsVisibleTo(userID int) bool {
+	return r.Status == StatusPublished || r.UserID == userID
+}
+
+// ChangeStatus moves the recipe to the status. It reports whether the recipe
+// has been published for the first time, the moment subscribers are told about it.
+func (r *Recipe) ChangeStatus(change *RecipeStatusChange, now time.Time) (firstPublish bool, err error) {
+	if r.Status != "" && !slices.Contains(statusTransitions[r.Status], change.Status) {
+		return false, ErrStatusTransition
+	}
+
+	r.PublishAt = nil
+	switch change.Status {
+	case StatusScheduled:
+		if change.PublishAt == nil {
+			return false, ErrPublishAtRequired
+		}
+		if !change.PublishAt.After(now) {
+			return false, ErrPublishAtPast
+		}
+		r.PublishAt = change.PublishAt
+	case StatusPublished:
+		if r.PublishedAt == nil {
+			r.PublishedAt = &now
+			firstPublish = true
+		}
+	}
+
+	r.Status = change.Status
+	return firstPublish, nil
+}
+
+// Publication is a scheduled recipe which has just been published.
+type Publication struct {
+	RecipeID  int
+	CreatorID int
+	// First is set when the recipe has never been published before.
+	First bool
+}
@@ -0,0 +1,125 @@
+package entities
+
+import (
+	"reflect"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// RecipeRevision is a version of a recipe as it was before an update.
+type RecipeRevision struct {
+	RecipeID  int       `json:"recipe_id"`
+	Version   int       `json:"version"`
+	Snapshot  *Recipe   `json:"snapshot,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// RevisionDiffParams selects the revisions to compare. Without To the
+// revision is compared with the current recipe.
+type RevisionDiffParams struct {
+	From int `form:"from" binding:"required,min=1"`
+	To   int `form:"to" binding:"omitempty,min=1"`
+}
+
+type RecipeFieldChange struct {
+	Field string `json:"field" example:"title"`
+	From  any    `json:"from"`
+	To    any    `json:"to"`
+}
+
+type RecipeDiff struct {
+	From    int                 `json:"from"`
+	To      int                 `json:"to,omitempty"`
+	Changes []RecipeFieldChange `json:"changes"`
+}
+
+// Clone copies the recipe so that changing one copy doesn't touch the other.
+func (r *Recipe) Clone() *Recipe {
+	clone := *r
+	clone.Ingredients = slices.Clone(r.Ingredients)
+	clone.Steps = slices.Clone(r.Steps)
+	clone.Tags = slices.Clone(r.Tags)
+	clone.Allergens = slices.Clone(r.Allergens)
+	clone.Diets = slices.Clone(r.Diets)
+	return &clone
+}
+
+// diffFields are the fields a revision is compared by, in the order of the recipe form.
+// Photos are left out: replaced photos are removed from the file storage, so
+// they can't be restored and a change of them would only confuse.
+var diffFields = []struct {
+	name  string
+	value func(r *Recipe) any
+}{
+	{"title", func(r *Recipe) any { return r.Title }},
+	{"about", func(r *Recipe) any { return r.About }},
+	{"complexity", func(r *Recipe) any { return r.Complexitiy }},
+	{"prep_minutes", func(r *Recipe) any { return r.PrepMinutes }},
+	{"cook_minutes", func(r *Recipe) any { return r.CookMinutes }},
+	{"servings", func(r *Recipe) any { return r.Servings }},
+	{"ingredients", func(r *Recipe) any { return r.Ingredients }},
+	{"steps", func(r *Recipe) any { return r.Steps }},
+	{"tags", func(r *Recipe) any { return r.TagSlugs() }},
+	{"allergens", func(r *Recipe) any { return r.Allergens }},
+	{"diets", func(r *Recipe) any { return r.Diets }},
+}
+
+// Diff lists the fields which differ between the recipes.
+func Diff(from, to *Recipe) []RecipeFieldChange {
+	changes := make([]RecipeFieldChange, 0, len(diffFields))
+	for _, field := range diffFields {
+		before, after := field.value(from), field.value(to)
+		if isEmpty(before) && isEmpty(after) {
+			continue
+		}
+		if !reflect.DeepEqual(before, after) {
+			changes = append(changes, RecipeFieldChange{Field: field.name, From: before, To: after})
+		}
+	}
+	return changes
+}
+
+// isEmpty tells nil and empty slices apart from values worth comparing.
+func isEmpty(value any) bool {
+	v := reflect.ValueOf(value)
+	return v.Kind() == reflect.Slice && v.Len() == 0
+}
+
+func (r *Recipe) TagSlugs() []string {
+	slugs := make([]string, 0, len(r.Tags))
+	for _, tag := range r.Tags {
+		slugs = append(slugs, tag.Slug)
+	}
+	return slugs
+}
+
+// ToUpdateRecipe turns the snapshot into an update which brings the recipe
+// back to it. Labels are replaced even when the snapshot has none. Photos
+// aren't part of the update: replaced photos are removed from the file storage.
+func (r *Recipe) ToUpdateRecipe() *UpdateRecipe {
+	update := &UpdateRecipe{
+		Title:       r.Title,
+		About:       r.About,
+		Complexitiy: r.Complexitiy,
+		CookingTime: CookingTime{
+			PrepTime: strconv.Itoa(r.PrepMinutes),
+			CookTime: strconv.Itoa(r.CookMinutes),
+		},
+		Servings:    r.Servings,
+		Ingridients: r.Ingridients,
+		Ingredients: slices.Clone(r.Ingredients),
+		Steps:       slices.Clone(r.Steps),
+		Tags:        r.TagSlugs(),
+		Allergens:   slices.Clone(r.Allergens),
+		Diets:       slices.Clone(r.Diets),
+
+		replaceLabels: true,
+	}
+	if len(update.Steps) == 0 {
+		update.Instructions = r.Instructions
+	}
+	update.CookingTime.prepMinutes = r.PrepMinutes
+	update.CookingTime.cookMinutes = r.CookMinutes
+	return update
+}
@@ -0,0 +1,161 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Homyakadze14/RecipeSite/internal/units"
+)
+
+const (
+	ExportText     = "text"
+	ExportMarkdown = "markdown"
+)
+
+type ShoppingListItem struct {
+	ID       int     `json:"id"`
+	Name     string  `json:"name" example:"flour"`
+	Quantity float64 `json:"quantity,omitempty" example:"0.5"`
+	Unit     string  `json:"unit,omitempty" example:"kg"`
+	Checked  bool    `json:"checked"`
+	// DisplayQuantity is the quantity rounded the way it is measured in a kitchen.
+	DisplayQuantity string `json:"display_quantity,omitempty" example:"0.5"`
+}
+
+// itemKey is the name of an item which is compared when merging.
+func itemKey(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// merge adds the ingredient to the item if it is the same ingredient in a compatible unit.
+func (i *ShoppingListItem) merge(ingredient *Ingredient) bool {
+	if i.Checked || itemKey(i.Name) != itemKey(ingredient.Name) {
+		return false
+	}
+
+	switch {
+	case ingredient.Quantity == 0 && ingredient.Unit == "":
+		// "salt to taste" is already on the list.
+	case i.Quantity == 0 && i.Unit == "":
+		i.Quantity, i.Unit = ingredient.Quantity, ingredient.Unit
+	default:
+		sum, unit, ok := units.Add(i.Quantity, i.Unit, ingredient.Quantity, ingredient.Unit)
+		if !ok {
+			return false
+		}
+		i.Quantity, i.Unit = sum, unit
+	}
+	return true
+}
+
+func (i *ShoppingListItem) String() string {
+	ingredient := Ingredient{Name: i.Name, Quantity: i.Quantity, Unit: i.Unit, DisplayQuantity: i.DisplayQuantity}
+	return ingredient.String()
+}
+
+type ShoppingList struct {
+	Items []ShoppingListItem `json:"items"`
+}
+
+// Add merges the ingredients into the unchecked items of the list. Items
+// bought already are kept as they are, so the ingredient is listed again.
+func (l *ShoppingList) Add(ingredients []Ingredient) {
+	for _, ingredient := range ingredients {
+		merged := false
+		for i := range l.Items {
+			if l.Items[i].merge(&ingredient) {
+				merged = true
+				break
+			}
+		}
+
+		if !merged {
+			l.Items = append(l.Items, ShoppingListItem{
+				Name:     ingredient.Name,
+				Quantity: ingredient.Quantity,
+				Unit:     ingredient.Unit,
+			})
+		}
+	}
+}
+
+// Round fills the display quantities of the items.
+func (l *ShoppingList) Round() {
+	for i := range l.Items {
+		if l.Items[i].Quantity != 0 {
+			l.Items[i].Quantity, l.Items[i].DisplayQuantity = units.Round(l.Items[i].Quantity, l.Items[i].Unit)
+		}
+	}
+}
+
+// Export renders the list as plain text or as a Markdown task list.
+func (l *ShoppingList) Export(format string) string {
+	var s strings.Builder
+	if format == ExportMarkdown {
+		s.WriteString("# Shopping list\n\n")
+	}
+
+	for _, item := range l.Items {
+		mark := " "
+		if item.Checked {
+			mark = "x"
+		}
+		if format == ExportMarkdown {
+			s.WriteString(fmt.Sprintf("- [%s] %s\n", mark, item.String()))
+		} else {
+			s.WriteString(fmt.Sprintf("[%s] %s\n", mark, item.String()))
+		}
+	}
+	return s.String()
+}
+
+type ShoppingListRecipe struct {
+	RecipeID int `json:"recipe_id" binding:"required"`
+	// Servings defaults to the servings of the recipe.
+	Servings int `json:"servings" binding:"omitempty,min=1,max=100"`
+}
+
+type AddShoppingListRecipes struct {
+	Recipes []ShoppingListRecipe `json:"recipes" binding:"required,min=1,max=50,dive"`
+}
+
+type AddShoppingListItem struct {
+	Name     string  `json:"name" binding:"required,min=1,max=100" example:"napkins"`
+	Quantity float64 `json:"quantity" binding:"min=0" example:"2"`
+	Unit     string  `json:"unit" binding:"max=20" example:"pcs"`
+}
+
+func (a *AddShoppingListItem) ToIngredient() Ingredient {
+	return Ingredient{Name: a.Name, Quantity: a.Quantity, Unit: a.Unit}
+}
+
+type UpdateShoppingListItem struct {
+	Name     string   `json:"name" binding:"max=100"`
+	Quantity *float64 `json:"quantity" binding:"omitempty,min=0"`
+	Unit     *string  `json:"unit" binding:"omitempty,max=20"`
+	Checked  *bool    `json:"checked"`
+}
+
+func (u *UpdateShoppingListItem) UpdateValues(item *ShoppingListItem) {
+	if u.Name != "" {
+		item.Name = u.Name
+	}
+	if u.Quantity != nil {
+		item.Quantity = *u.Quantity
+	}
+	if u.Unit != nil {
+		item.Unit = *u.Unit
+	}
+	if u.Checked != nil {
+		item.Checked = *u.Checked
+	}
+}
+
+type ClearShoppingList struct {
+	// Checked clears only the items which are bought already.
+	Checked bool `form:"checked"`
+}
+
+type ShoppingListExport struct {
+	Format string `form:"format" binding:"omitempty,oneof=text markdown" enums:"text,markdown" default:"text"`
+}
@@ -0,0 +1,41 @@
+package entities
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+type RecipeStep struct {
+	Text     string `json:"text" binding:"required,min=1,max=2000" example:"Mix the flour with eggs"`
+	Duration int    `json:"duration,omitempty" binding:"min=0,max=10080" example:"15"`
+	PhotoURL string `json:"photo_url,omitempty"`
+}
+
+var stepNumberRe = regexp.MustCompile(`^\s*\d+[.)]\s*`)
+
+// ParseSteps turns legacy instructions into steps, one per non-empty line.
+func ParseSteps(text string) []RecipeStep {
+	steps := make([]RecipeStep, 0, 10)
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(stepNumberRe.ReplaceAllString(line, ""))
+		if line == "" {
+			continue
+		}
+		steps = append(steps, RecipeStep{Text: line})
+	}
+	return steps
+}
+
+// FormatSteps renders steps as the numbered legacy instructions text.
+func FormatSteps(steps []RecipeStep) string {
+	lines := make([]string, 0, len(steps))
+	for i, step := range steps {
+		lines = append(lines, fmt.Sprintf("%v. %s", i+1, step.Text))
+	}
+	text := []rune(strings.Join(lines, "\n"))
+	if len(text) > legacyTextLimit {
+		text = text[:legacyTextLimit]
+	}
+	return string(text)
+}
@@ -0,0 +1,20 @@
+package entities
+
+type Tag struct {
+	Slug string `json:"slug" example:"breakfast"`
+	Name string `json:"name" example:"Breakfast"`
+	Kind string `json:"kind" example:"meal" enums:"meal,category,cuisine"`
+}
+
+type TagWithCount struct {
+	Tag
+	RecipesCount int `json:"recipes_count"`
+}
+
+func tagsFromSlugs(slugs []string) []Tag {
+	tags := make([]Tag, 0, len(slugs))
+	for _, slug := range slugs {
+		tags = append(tags, Tag{Slug: slug})
+	}
+	return tags
+}
@@ -0,0 +1,19 @@
+package entities
+
+import "time"
+
+// Trash keeps deleted recipes and comments of a user until they are purged.
+type Trash struct {
+	Recipes  []Recipe       `json:"recipes"`
+	Comments []TrashComment `json:"comments"`
+	// PurgeAfter is how long deleted items may be restored.
+	PurgeAfter string `json:"purge_after" example:"720h0m0s"`
+}
+
+type TrashComment struct {
+	ID        int       `json:"id"`
+	RecipeID  int       `json:"recipe_id"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
@@ -6,13 +6,14 @@ import (
 )
 
 type User struct {
-	ID        int       `json:"id"`
-	Email     string    `json:"email" binding:"required,email"`
-	Login     string    `json:"login" binding:"required,min=3,max=20"`
-	Password  string    `json:"password" binding:"required,min=8,max=50"`
-	IconURL   string    `json:"icon_url"`
-	About     string    `json:"about" binding:"max=1500"`
-	CreatedAt time.Time `json:"created_at"`
+	ID        int        `json:"id"`
+	Email     string     `json:"email" binding:"required,email"`
+	Login     string     `json:"login" binding:"required,min=3,max=20"`
+	Password  string     `json:"password" binding:"required,min=8,max=50"`
+	IconURL   string     `json:"icon_url"`
+	About     string     `json:"about" binding:"max=1500"`
+	CreatedAt time.Time  `json:"created_at"`
+	DeletedAt *time.Time `json:"-"`
 }
 
 type AuthUser struct {
@@ -33,6 +34,9 @@ type UserInfo struct {
 	IsSubscribed  bool               `json:"is_subscribed"`
 	Recipies      []RecipeWithAuthor `json:"recipies"`
 	LikedRecipies []RecipeWithAuthor `json:"liked_recipies"`
+	Collections   []Collection       `json:"collections"`
+	// CookHistory is shown to the user only.
+	CookHistory []CookLog `json:"cook_history,omitempty"`
 }
 
 type Author struct {
@@ -0,0 +1,107 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Homyakadze14/RecipeSite/internal/entities"
+	"github.com/Homyakadze14/RecipeSite/internal/usecases"
+	"github.com/Homyakadze14/RecipeSite/pkg/postgres"
+)
+
+const bookmarkCursorField = "bookmark"
+
+type BookmarkRepo struct {
+	*postgres.Postgres
+}
+
+func NewBookmarkRepository(pg *postgres.Postgres) *BookmarkRepo {
+	return &BookmarkRepo{pg}
+}
+
+func (r *BookmarkRepo) IsBookmarked(ctx context.Context, bookmark *entities.Bookmark) (bool, error) {
+	row := r.Pool.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM bookmarks WHERE user_id=$1 AND recipe_id=$2)",
+		bookmark.UserID, bookmark.RecipeID)
+
+	var bookmarked bool
+	err := row.Scan(&bookmarked)
+	if err != nil {
+		return false, fmt.Errorf("BookmarkRepo - IsBookmarked - row.Scan: %w", err)
+	}
+	return bookmarked, nil
+}
+
+// Bookmark saves the recipe for later. Only recipes the user may see can be saved.
+func (r *BookmarkRepo) Bookmark(ctx context.Context, bookmark *entities.Bookmark) error {
+	res, err := r.Pool.Exec(ctx, "INSERT INTO bookmarks(user_id, recipe_id) SELECT $1, $2"+
+		" WHERE EXISTS (SELECT 1 FROM recipes WHERE id=$2 AND deleted_at IS NULL AND (status=$3 OR user_id=$1))",
+		bookmark.UserID, bookmark.RecipeID, entities.StatusPublished)
+	if err != nil {
+		if strings.Contains(err.Error(), "SQLSTATE 23505") {
+			return usecases.ErrAlreadyBookmarked
+		}
+		return fmt.Errorf("BookmarkRepo - Bookmark - r.Pool.Exec: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return usecases.ErrRecipeNotFound
+	}
+	return nil
+}
+
+func (r *BookmarkRepo) Unbookmark(ctx context.Context, bookmark *entities.Bookmark) error {
+	res, err := r.Pool.Exec(ctx, "DELETE FROM bookmarks WHERE user_id=$1 AND recipe_id=$2", bookmark.UserID, bookmark.RecipeID)
+	if err != nil {
+		return fmt.Errorf("BookmarkRepo - Unbookmark - r.Pool.Exec: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return usecases.ErrNotBookmarked
+	}
+	return nil
+}
+
+// GetRecipes gets a page of recipes saved by the user, the last saved first.
+func (r *BookmarkRepo) GetRecipes(ctx context.Context, userID int, page *entities.RecipePage) ([]entities.Recipe, error) {
+	var request strings.Builder
+	builder := &queryBuilder{params: make([]interface{}, 0, 4)}
+
+	if page.Cursor != "" {
+		after, err := decodeCursor(page.Cursor)
+		if err != nil || after.Field != bookmarkCursorField {
+			return nil, usecases.ErrBadCursor
+		}
+		builder.where("bookmarks.id < " + builder.arg(after.ID))
+	}
+
+	user := builder.arg(userID)
+	builder.where("bookmarks.user_id = " + user)
+	builder.where("(recipes.status = " + builder.arg(entities.StatusPublished) + " OR recipes.user_id = " + user + ")")
+	builder.where("recipes.deleted_at IS NULL")
+	request.WriteString("SELECT " + recipeFields + ", bookmarks.id FROM bookmarks JOIN recipes ON recipes.id = bookmarks.recipe_id")
+	request.WriteString(builder.whereClause())
+	request.WriteString(" ORDER BY bookmarks.id DESC LIMIT " + builder.arg(page.Limit))
+
+	rows, err := r.Pool.Query(ctx, request.String(), builder.params...)
+	if err != nil {
+		return nil, fmt.Errorf("BookmarkRepo - GetRecipes - r.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	recipes := make([]entities.Recipe, 0, page.Limit)
+	for rows.Next() {
+		var recipe entities.Recipe
+		var bookmarkID int
+		err := scanRecipe(rows, &recipe, &bookmarkID)
+		if err != nil {
+			return nil, fmt.Errorf("BookmarkRepo - GetRecipes - rows.Scan: %w", err)
+		}
+
+		recipe.Cursor = (&cursor{Field: bookmarkCursorField, ID: bookmarkID}).encode()
+		recipes = append(recipes, recipe)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("BookmarkRepo - GetRecipes - rows.Err: %w", err)
+	}
+
+	return recipes, nil
+}
@@ -0,0 +1,314 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Homyakadze14/RecipeSite/internal/entities"
+	"github.com/Homyakadze14/RecipeSite/internal/usecases"
+	"github.com/Homyakadze14/RecipeSite/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+)
+
+// collectionFields end with the count of recipes in the collection which are
+// published or written by the owner of the collection.
+const collectionFields = "collections.id, collections.user_id, collections.title, collections.about, " +
+	"collections.cover_url, collections.is_public, collections.created_at, collections.updated_at, " +
+	"(SELECT COUNT(*) FROM collection_recipes JOIN recipes ON recipes.id = collection_recipes.recipe_id" +
+	" WHERE collection_recipes.collection_id = collections.id AND recipes.deleted_at IS NULL" +
+	" AND (recipes.status = '" + entities.StatusPublished + "' OR recipes.user_id = collections.user_id))"
+
+func scanCollection(row pgx.Row, collection *entities.Collection) error {
+	return row.Scan(&collection.ID, &collection.UserID, &collection.Title, &collection.About,
+		&collection.CoverURL, &collection.IsPublic, &collection.CreatedAt, &collection.UpdatedAt, &collection.RecipesCount)
+}
+
+type CollectionRepo struct {
+	*postgres.Postgres
+}
+
+func NewCollectionRepository(pg *postgres.Postgres) *CollectionRepo {
+	return &CollectionRepo{pg}
+}
+
+func getCollections(ctx context.Context, q querier, userID int, hidden bool) ([]entities.Collection, error) {
+	rows, err := q.Query(ctx, "SELECT "+collectionFields+" FROM collections WHERE user_id=$1 AND (is_public OR $2)"+
+		" ORDER BY created_at DESC", userID, hidden)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	collections := make([]entities.Collection, 0, constArraySize)
+	for rows.Next() {
+		var collection entities.Collection
+		err := scanCollection(rows, &collection)
+		if err != nil {
+			return nil, err
+		}
+		collections = append(collections, collection)
+	}
+
+	return collections, rows.Err()
+}
+
+// GetAll gets collections of the user. Private ones are got only when hidden is true.
+func (r *CollectionRepo) GetAll(ctx context.Context, userID int, hidden bool) ([]entities.Collection, error) {
+	collections, err := getCollections(ctx, r.Pool, userID, hidden)
+	if err != nil {
+		return nil, fmt.Errorf("CollectionRepo - GetAll - getCollections: %w", err)
+	}
+	return collections, nil
+}
+
+func (r *UserRepo) GetPublicCollections(ctx context.Context, userID int) ([]entities.Collection, error) {
+	collections, err := getCollections(ctx, r.Pool, userID, false)
+	if err != nil {
+		return nil, fmt.Errorf("UserRepo - GetPublicCollections - getCollections: %w", err)
+	}
+	return collections, nil
+}
+
+func (r *CollectionRepo) Get(ctx context.Context, id int) (*entities.Collection, error) {
+	row := r.Pool.QueryRow(ctx, "SELECT "+collectionFields+" FROM collections WHERE id=$1", id)
+
+	collection := &entities.Collection{}
+	err := scanCollection(row, collection)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, usecases.ErrCollectionNotFound
+		}
+		return nil, fmt.Errorf("CollectionRepo - Get - row.Scan: %w", err)
+	}
+	return collection, nil
+}
+
+func (r *CollectionRepo) Create(ctx context.Context, collection *entities.Collection) (id int, err error) {
+	row := r.Pool.QueryRow(ctx, "INSERT INTO collections(user_id, title, about, cover_url, is_public, created_at, updated_at)"+
+		" VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING id",
+		collection.UserID, collection.Title, collection.About, collection.CoverURL, collection.IsPublic, time.Now(), time.Now())
+
+	err = row.Scan(&id)
+	if err != nil {
+		return -1, fmt.Errorf("CollectionRepo - Create - row.Scan: %w", err)
+	}
+	return id, nil
+}
+
+func (r *CollectionRepo) Update(ctx context.Context, collection *entities.Collection) error {
+	_, err := r.Pool.Exec(ctx, "UPDATE collections SET title=$1, about=$2, cover_url=$3, is_public=$4, updated_at=$5 WHERE id=$6",
+		collection.Title, collection.About, collection.CoverURL, collection.IsPublic, time.Now(), collection.ID)
+	if err != nil {
+		return fmt.Errorf("CollectionRepo - Update - r.Pool.Exec: %w", err)
+	}
+	return nil
+}
+
+func (r *CollectionRepo) Delete(ctx context.Context, id int) error {
+	_, err := r.Pool.Exec(ctx, "DELETE FROM collections WHERE id=$1", id)
+	if err != nil {
+		return fmt.Errorf("CollectionRepo - Delete - r.Pool.Exec: %w", err)
+	}
+	return nil
+}
+
+// GetRecipes gets the recipes of the collection the viewer may see in the order of the collection.
+func (r *CollectionRepo) GetRecipes(ctx context.Context, collectionID, viewerID int) ([]entities.RecipeWithAuthor, error) {
+	rows, err := r.Pool.Query(ctx, "SELECT "+recipeFields+", users.login, users.icon_url FROM collection_recipes"+
+		" JOIN recipes ON recipes.id = collection_recipes.recipe_id JOIN users ON users.id = recipes.user_id"+
+		" WHERE collection_recipes.collection_id=$1 AND recipes.deleted_at IS NULL AND (recipes.status=$2 OR recipes.user_id=$3)"+
+		" ORDER BY collection_recipes.position",
+		collectionID, entities.StatusPublished, viewerID)
+	if err != nil {
+		return nil, fmt.Errorf("CollectionRepo - GetRecipes - r.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	recipes := make([]entities.RecipeWithAuthor, 0, constArraySize)
+	for rows.Next() {
+		var recipe entities.Recipe
+		author := &entities.Author{}
+		err := scanRecipe(rows, &recipe, &author.Login, &author.IconURL)
+		if err != nil {
+			return nil, fmt.Errorf("CollectionRepo - GetRecipes - rows.Scan: %w", err)
+		}
+
+		rwa := recipe.ToRecipeWithAuthor()
+		rwa.Author = author
+		recipes = append(recipes, *rwa)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("CollectionRepo - GetRecipes - rows.Err: %w", err)
+	}
+
+	return recipes, nil
+}
+
+// AddRecipe puts the recipe into the collection. The owner of the collection
+// may add published recipes and own ones.
+func (r *CollectionRepo) AddRecipe(ctx context.Context, collection *entities.Collection, item *entities.CollectionRecipe) error {
+	tx, err := r.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("CollectionRepo - AddRecipe - r.Pool.Begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Lock the collection so positions are not taken twice. The position is
+	// sent for the recipes the owner sees, so the new recipe takes the place
+	// of the visible recipe at it, or goes after all the recipes.
+	var position int
+	err = tx.QueryRow(ctx, "SELECT coalesce(CASE WHEN $4 > 0 THEN (SELECT collection_recipes.position FROM collection_recipes"+
+		" JOIN recipes ON recipes.id = collection_recipes.recipe_id WHERE collection_recipes.collection_id=$1"+
+		" AND recipes.deleted_at IS NULL AND (recipes.status=$2 OR recipes.user_id=$3)"+
+		" ORDER BY collection_recipes.position OFFSET greatest($4 - 1, 0) LIMIT 1) END,"+
+		" (SELECT coalesce(max(position), 0) + 1 FROM collection_recipes WHERE collection_id=$1))"+
+		" FROM collections WHERE id=$1 FOR UPDATE",
+		collection.ID, entities.StatusPublished, collection.UserID, item.Position).Scan(&position)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return usecases.ErrCollectionNotFound
+		}
+		return fmt.Errorf("CollectionRepo - AddRecipe - tx.QueryRow: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE collection_recipes SET position=position+1 WHERE collection_id=$1 AND position>=$2",
+		collection.ID, position)
+	if err != nil {
+		return fmt.Errorf("CollectionRepo - AddRecipe - tx.Exec: %w", err)
+	}
+
+	res, err := tx.Exec(ctx, "INSERT INTO collection_recipes(collection_id, recipe_id, position) SELECT $1, $2, $3"+
+		" WHERE EXISTS (SELECT 1 FROM recipes WHERE id=$2 AND deleted_at IS NULL AND (status=$4 OR user_id=$5))",
+		collection.ID, item.RecipeID, position, entities.StatusPublished, collection.UserID)
+	if err != nil {
+		if strings.Contains(err.Error(), "SQLSTATE 23505") {
+			return usecases.ErrRecipeInCollection
+		}
+		return fmt.Errorf("CollectionRepo - AddRecipe - tx.Exec: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return usecases.ErrRecipeNotFound
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE collections SET updated_at=$1 WHERE id=$2", time.Now(), collection.ID)
+	if err != nil {
+		return fmt.Errorf("CollectionRepo - AddRecipe - tx.Exec: %w", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("CollectionRepo - AddRecipe - tx.Commit: %w", err)
+	}
+	return nil
+}
+
+func (r *CollectionRepo) RemoveRecipe(ctx context.Context, collectionID, recipeID int) error {
+	tx, err := r.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("CollectionRepo - RemoveRecipe - r.Pool.Begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var position int
+	err = tx.QueryRow(ctx, "DELETE FROM collection_recipes WHERE collection_id=$1 AND recipe_id=$2 RETURNING position",
+		collectionID, recipeID).Scan(&position)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return usecases.ErrRecipeNotInCollection
+		}
+		return fmt.Errorf("CollectionRepo - RemoveRecipe - tx.QueryRow: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE collection_recipes SET position=position-1 WHERE collection_id=$1 AND position>$2",
+		collectionID, position)
+	if err != nil {
+		return fmt.Errorf("CollectionRepo - RemoveRecipe - tx.Exec: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE collections SET updated_at=$1 WHERE id=$2", time.Now(), collectionID)
+	if err != nil {
+		return fmt.Errorf("CollectionRepo - RemoveRecipe - tx.Exec: %w", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("CollectionRepo - RemoveRecipe - tx.Commit: %w", err)
+	}
+	return nil
+}
+
+// Reorder puts the recipes of the collection in the order of ids. The ids
+// must list every recipe of the collection the owner sees exactly once, the
+// hidden ones (trashed or no longer published) keep their order after them.
+func (r *CollectionRepo) Reorder(ctx context.Context, collection *entities.Collection, ids []int) error {
+	tx, err := r.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("CollectionRepo - Reorder - r.Pool.Begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, "SELECT id FROM collections WHERE id=$1 FOR UPDATE", collection.ID)
+	if err != nil {
+		return fmt.Errorf("CollectionRepo - Reorder - tx.Exec: %w", err)
+	}
+
+	rows, err := tx.Query(ctx, "SELECT collection_recipes.recipe_id,"+
+		" recipes.deleted_at IS NULL AND (recipes.status=$2 OR recipes.user_id=$3) FROM collection_recipes"+
+		" JOIN recipes ON recipes.id = collection_recipes.recipe_id WHERE collection_recipes.collection_id=$1"+
+		" ORDER BY collection_recipes.position",
+		collection.ID, entities.StatusPublished, collection.UserID)
+	if err != nil {
+		return fmt.Errorf("CollectionRepo - Reorder - tx.Query: %w", err)
+	}
+
+	visible := make([]int, 0, len(ids))
+	hidden := make([]int, 0, constArraySize)
+	for rows.Next() {
+		var id int
+		var isVisible bool
+		err = rows.Scan(&id, &isVisible)
+		if err != nil {
+			rows.Close()
+			return fmt.Errorf("CollectionRepo - Reorder - rows.Scan: %w", err)
+		}
+		if isVisible {
+			visible = append(visible, id)
+		} else {
+			hidden = append(hidden, id)
+		}
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("CollectionRepo - Reorder - rows.Err: %w", err)
+	}
+
+	sorted := slices.Clone(ids)
+	slices.Sort(sorted)
+	slices.Sort(visible)
+	if !slices.Equal(sorted, visible) {
+		return usecases.ErrCollectionOrder
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE collection_recipes SET position=ord.position"+
+		" FROM unnest($2::int[]) WITH ORDINALITY AS ord(recipe_id, position)"+
+		" WHERE collection_recipes.collection_id=$1 AND collection_recipes.recipe_id=ord.recipe_id",
+		collection.ID, append(slices.Clone(ids), hidden...))
+	if err != nil {
+		return fmt.Errorf("CollectionRepo - Reorder - tx.Exec: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE collections SET updated_at=$1 WHERE id=$2", time.Now(), collection.ID)
+	if err != nil {
+		return fmt.Errorf("CollectionRepo - Reorder - tx.Exec: %w", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("CollectionRepo - Reorder - tx.Commit: %w", err)
+	}
+	return nil
+}
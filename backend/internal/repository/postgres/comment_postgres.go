@@ -17,25 +17,31 @@ type CommentRepo struct {
 	*postgres.Postgres
 }
 
+const commentFields = "id, user_id, recipe_id, text, created_at, updated_at"
+
 func NewCommentRepository(pg *postgres.Postgres) *CommentRepo {
 	return &CommentRepo{pg}
 }
 
 func (r *CommentRepo) Save(ctx context.Context, cm *entities.Comment) error {
-	_, err := r.Pool.Exec(ctx, "INSERT INTO comments(user_id, recipe_id, text, created_at, updated_at) VALUES ($1,$2,$3,$4,$5)",
-		cm.UserID, cm.RecipeID, cm.Text, time.Now(), time.Now())
+	res, err := r.Pool.Exec(ctx, "INSERT INTO comments(user_id, recipe_id, text, created_at, updated_at) SELECT $1,$2,$3,$4,$5"+
+		" WHERE EXISTS (SELECT 1 FROM recipes WHERE id=$2 AND deleted_at IS NULL AND (status=$6 OR user_id=$1))",
+		cm.UserID, cm.RecipeID, cm.Text, time.Now(), time.Now(), entities.StatusPublished)
 	if err != nil {
 		if strings.Contains(err.Error(), "SQLSTATE 23503") {
 			return usecases.ErrRecipeNotFound
 		}
 		return fmt.Errorf("CommentRepo - Save - r.Pool.Exec: %w", err)
 	}
+	if res.RowsAffected() == 0 {
+		return usecases.ErrRecipeNotFound
+	}
 
 	return nil
 }
 
 func (r *CommentRepo) Update(ctx context.Context, cm *entities.CommentUpdate) error {
-	_, err := r.Pool.Exec(ctx, "UPDATE comments SET text=$1, updated_at=$2 WHERE id=$3", cm.Text, time.Now(), cm.ID)
+	_, err := r.Pool.Exec(ctx, "UPDATE comments SET text=$1, updated_at=$2 WHERE id=$3 AND deleted_at IS NULL", cm.Text, time.Now(), cm.ID)
 	if err != nil {
 		if strings.Contains(err.Error(), "SQLSTATE 23503") {
 			return usecases.ErrRecipeNotFound
@@ -46,8 +52,9 @@ func (r *CommentRepo) Update(ctx context.Context, cm *entities.CommentUpdate) er
 	return nil
 }
 
+// Delete moves the comment to the trash of its author.
 func (r *CommentRepo) Delete(ctx context.Context, cm *entities.CommentDelete) error {
-	_, err := r.Pool.Exec(ctx, "DELETE FROM comments WHERE id=$1", cm.ID)
+	_, err := r.Pool.Exec(ctx, "UPDATE comments SET deleted_at=$1 WHERE id=$2 AND deleted_at IS NULL", time.Now(), cm.ID)
 	if err != nil {
 		return fmt.Errorf("CommentRepo - Delete - r.Pool.Exec: %w", err)
 	}
@@ -56,7 +63,7 @@ func (r *CommentRepo) Delete(ctx context.Context, cm *entities.CommentDelete) er
 }
 
 func (r *CommentRepo) GetByID(ctx context.Context, id int) (*entities.Comment, error) {
-	row := r.Pool.QueryRow(ctx, "SELECT * FROM comments WHERE id=$1", id)
+	row := r.Pool.QueryRow(ctx, "SELECT "+commentFields+" FROM comments WHERE id=$1 AND deleted_at IS NULL", id)
 	comment := &entities.Comment{}
 
 	err := row.Scan(&comment.ID, &comment.UserID, &comment.RecipeID, &comment.Text, &comment.CreatedAt, &comment.UpdatedAt)
@@ -71,7 +78,7 @@ func (r *CommentRepo) GetByID(ctx context.Context, id int) (*entities.Comment, e
 }
 
 func (r *CommentRepo) GetAll(ctx context.Context, recipeID int) ([]entities.Comment, error) {
-	rows, err := r.Pool.Query(ctx, "SELECT * FROM comments WHERE recipe_id=$1 ORDER BY created_at", recipeID)
+	rows, err := r.Pool.Query(ctx, "SELECT "+commentFields+" FROM comments WHERE recipe_id=$1 AND deleted_at IS NULL ORDER BY created_at", recipeID)
 	if err != nil {
 		return nil, fmt.Errorf("CommentRepo - GetAll - r.Pool.Query: %w", err)
 	}
@@ -0,0 +1,148 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Homyakadze14/RecipeSite/internal/entities"
+	"github.com/Homyakadze14/RecipeSite/internal/usecases"
+	"github.com/Homyakadze14/RecipeSite/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+)
+
+const cookLogFields = "cook_logs.id, cook_logs.user_id, cook_logs.recipe_id, cook_logs.cooked_on, cook_logs.note," +
+	" cook_logs.photo_url, cook_logs.created_at"
+
+type CookLogRepo struct {
+	*postgres.Postgres
+}
+
+func NewCookLogRepository(pg *postgres.Postgres) *CookLogRepo {
+	return &CookLogRepo{pg}
+}
+
+func scanCookLog(row pgx.Row, log *entities.CookLog, extra ...any) error {
+	var cookedOn time.Time
+	err := row.Scan(append([]any{&log.ID, &log.UserID, &log.RecipeID, &cookedOn, &log.Note,
+		&log.PhotoURL, &log.CreatedAt}, extra...)...)
+	if err != nil {
+		return err
+	}
+	log.CookedOn = cookedOn.Format(entities.DateLayout)
+	return nil
+}
+
+func (r *CookLogRepo) Get(ctx context.Context, id int) (*entities.CookLog, error) {
+	row := r.Pool.QueryRow(ctx, "SELECT "+cookLogFields+" FROM cook_logs WHERE id=$1", id)
+
+	log := &entities.CookLog{}
+	err := scanCookLog(row, log)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, usecases.ErrCookLogNotFound
+		}
+		return nil, fmt.Errorf("CookLogRepo - Get - row.Scan: %w", err)
+	}
+	return log, nil
+}
+
+func (r *CookLogRepo) Create(ctx context.Context, log *entities.CookLog, cookedOn time.Time) (id int, err error) {
+	row := r.Pool.QueryRow(ctx, "INSERT INTO cook_logs(user_id, recipe_id, cooked_on, note, photo_url, created_at)"+
+		" VALUES ($1,$2,$3,$4,$5,$6) RETURNING id",
+		log.UserID, log.RecipeID, cookedOn, log.Note, log.PhotoURL, time.Now())
+
+	err = row.Scan(&id)
+	if err != nil {
+		return -1, fmt.Errorf("CookLogRepo - Create - row.Scan: %w", err)
+	}
+	return id, nil
+}
+
+func (r *CookLogRepo) Delete(ctx context.Context, id int) error {
+	_, err := r.Pool.Exec(ctx, "DELETE FROM cook_logs WHERE id=$1", id)
+	if err != nil {
+		return fmt.Errorf("CookLogRepo - Delete - r.Pool.Exec: %w", err)
+	}
+	return nil
+}
+
+// CookedCount counts the logs of the recipe, logs of users in the trash are left out.
+func (r *CookLogRepo) CookedCount(ctx context.Context, recipeID int) (int, error) {
+	row := r.Pool.QueryRow(ctx, "SELECT count(*) FROM cook_logs JOIN users ON users.id = cook_logs.user_id"+
+		" WHERE cook_logs.recipe_id=$1 AND users.deleted_at IS NULL", recipeID)
+
+	var count int
+	err := row.Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("CookLogRepo - CookedCount - row.Scan: %w", err)
+	}
+	return count, nil
+}
+
+// GetPhotos gets the logs of the recipe which have photos, the latest first.
+// Logs of users in the trash are left out. Zero limit gets all of them.
+func (r *CookLogRepo) GetPhotos(ctx context.Context, recipeID, limit int) ([]entities.CookLog, error) {
+	rows, err := r.Pool.Query(ctx, "SELECT "+cookLogFields+", users.login, users.icon_url FROM cook_logs"+
+		" JOIN users ON users.id = cook_logs.user_id"+
+		" WHERE cook_logs.recipe_id=$1 AND cook_logs.photo_url <> '' AND users.deleted_at IS NULL"+
+		" ORDER BY cook_logs.cooked_on DESC, cook_logs.id DESC LIMIT NULLIF($2, 0)", recipeID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("CookLogRepo - GetPhotos - r.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	logs := make([]entities.CookLog, 0, constArraySize)
+	for rows.Next() {
+		var log entities.CookLog
+		log.Author = &entities.Author{}
+		err = scanCookLog(rows, &log, &log.Author.Login, &log.Author.IconURL)
+		if err != nil {
+			return nil, fmt.Errorf("CookLogRepo - GetPhotos - rows.Scan: %w", err)
+		}
+		logs = append(logs, log)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("CookLogRepo - GetPhotos - rows.Err: %w", err)
+	}
+
+	return logs, nil
+}
+
+// GetCookHistory gets the logs of the user with the recipes the user may
+// still see, the latest first.
+func (r *UserRepo) GetCookHistory(ctx context.Context, userID int) ([]entities.CookLog, error) {
+	rows, err := r.Pool.Query(ctx, "SELECT "+recipeFields+", users.login, users.icon_url, "+cookLogFields+" FROM cook_logs"+
+		" JOIN recipes ON recipes.id = cook_logs.recipe_id JOIN users ON users.id = recipes.user_id"+
+		" WHERE cook_logs.user_id=$1 AND recipes.deleted_at IS NULL AND (recipes.status=$2 OR recipes.user_id=$1)"+
+		" ORDER BY cook_logs.cooked_on DESC, cook_logs.id DESC",
+		userID, entities.StatusPublished)
+	if err != nil {
+		return nil, fmt.Errorf("UserRepo - GetCookHistory - r.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	logs := make([]entities.CookLog, 0, constArraySize)
+	for rows.Next() {
+		var log entities.CookLog
+		var recipe entities.Recipe
+		var cookedOn time.Time
+		author := &entities.Author{}
+		err = scanRecipe(rows, &recipe, &author.Login, &author.IconURL, &log.ID, &log.UserID, &log.RecipeID,
+			&cookedOn, &log.Note, &log.PhotoURL, &log.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("UserRepo - GetCookHistory - rows.Scan: %w", err)
+		}
+
+		log.CookedOn = cookedOn.Format(entities.DateLayout)
+		log.Recipe = recipe.ToRecipeWithAuthor()
+		log.Recipe.Author = author
+		logs = append(logs, log)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("UserRepo - GetCookHistory - rows.Err: %w", err)
+	}
+
+	return logs, nil
+}
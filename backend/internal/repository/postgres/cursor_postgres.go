@@ -0,0 +1,32 @@
+package repo
+
+import (
+	"encoding/base64"
+	"encoding/json"
+)
+
+// cursor points right after a row in a list ordered by Field.
+// Value is the text representation of the order key of the row.
+type cursor struct {
+	Field string `json:"f"`
+	Value string `json:"v"`
+	ID    int    `json:"id"`
+}
+
+func (c *cursor) encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(s string) (*cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &cursor{}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
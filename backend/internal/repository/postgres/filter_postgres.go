@@ -0,0 +1,89 @@
+package repo
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Homyakadze14/RecipeSite/internal/entities"
+)
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// queryBuilder collects WHERE conditions and their parameters.
+type queryBuilder struct {
+	conditions []string
+	params     []interface{}
+}
+
+// arg adds the parameter and returns its placeholder.
+func (b *queryBuilder) arg(value interface{}) string {
+	b.params = append(b.params, value)
+	return fmt.Sprintf("$%v", len(b.params))
+}
+
+func (b *queryBuilder) where(condition string) {
+	b.conditions = append(b.conditions, condition)
+}
+
+func (b *queryBuilder) whereClause() string {
+	if len(b.conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(b.conditions, " AND ")
+}
+
+// applyRecipeFilter adds the conditions of the filter to the query.
+func applyRecipeFilter(b *queryBuilder, filter *entities.RecipeFilter) {
+	b.where("recipes.deleted_at IS NULL")
+	// Only the author may look through recipes which aren't published.
+	if filter.Status == "" || filter.Status == entities.StatusPublished {
+		b.where("recipes.status = " + b.arg(entities.StatusPublished))
+	} else {
+		b.where("recipes.status = " + b.arg(filter.Status))
+		b.where("recipes.user_id = " + b.arg(filter.ViewerID))
+	}
+	if filter.ComplexityMin != 0 {
+		b.where("recipes.complexitiy >= " + b.arg(filter.ComplexityMin))
+	}
+	if filter.ComplexityMax != 0 {
+		b.where("recipes.complexitiy <= " + b.arg(filter.ComplexityMax))
+	}
+	if filter.MaxCookingTime != 0 {
+		b.where("recipes.total_minutes <= " + b.arg(filter.MaxCookingTime))
+	}
+	if filter.Author != "" {
+		b.where("recipes.user_id = (SELECT id FROM users WHERE login = " + b.arg(filter.Author) + ")")
+	}
+	for _, name := range filter.IncludeIngredients {
+		b.where("EXISTS (SELECT 1 FROM ingredients WHERE ingredients.recipe_id = recipes.id AND ingredients.name ILIKE '%'||" +
+			b.arg(likeEscaper.Replace(name)) + "||'%')")
+	}
+	if len(filter.ExcludeIngredients) != 0 {
+		names := make([]string, 0, len(filter.ExcludeIngredients))
+		for _, name := range filter.ExcludeIngredients {
+			names = append(names, likeEscaper.Replace(name))
+		}
+		b.where("NOT EXISTS (SELECT 1 FROM ingredients, unnest(" + b.arg(names) + "::text[]) excluded" +
+			" WHERE ingredients.recipe_id = recipes.id AND ingredients.name ILIKE '%'||excluded||'%')")
+	}
+	if filter.CreatedAfter != nil {
+		b.where("recipes.created_at >= " + b.arg(*filter.CreatedAfter))
+	}
+	if filter.CreatedBefore != nil {
+		b.where("recipes.created_at < " + b.arg(*filter.CreatedBefore))
+	}
+	for _, slug := range filter.Tags {
+		b.where("EXISTS (SELECT 1 FROM recipe_tags JOIN tags ON tags.id = recipe_tags.tag_id" +
+			" WHERE recipe_tags.recipe_id = recipes.id AND tags.slug = " + b.arg(slug) + ")")
+	}
+	if len(filter.ExcludeAllergens) != 0 {
+		b.where("NOT recipes.allergens && " + b.arg(filter.ExcludeAllergens) + "::text[]")
+	}
+	if len(filter.Diet) != 0 {
+		b.where("recipes.diets @> " + b.arg(filter.Diet) + "::text[]")
+	}
+	if filter.MinLikes != 0 {
+		b.where("(SELECT count(*) FROM likes JOIN users ON users.id = likes.user_id" +
+			" WHERE likes.recipe_id = recipes.id AND users.deleted_at IS NULL) >= " + b.arg(filter.MinLikes))
+	}
+}
@@ -0,0 +1,81 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Homyakadze14/RecipeSite/internal/entities"
+	"github.com/jackc/pgx/v5"
+)
+
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+// ForksCount counts published forks of the recipe.
+func (r *RecipeRepo) ForksCount(ctx context.Context, recipeID int) (int, error) {
+	row := r.Pool.QueryRow(ctx, "SELECT COUNT(*) FROM recipes WHERE forked_from=$1 AND status=$2 AND deleted_at IS NULL",
+		recipeID, entities.StatusPublished)
+
+	var count int
+	err := row.Scan(&count)
+	if err != nil {
+		return -1, fmt.Errorf("RecipeRepo - ForksCount - row.Scan: %w", err)
+	}
+	return count, nil
+}
+
+// GetLineage gets the recipes the recipe descends from, the closest first.
+// Recipes the viewer can't see are left out.
+func (r *RecipeRepo) GetLineage(ctx context.Context, recipeID, viewerID int) ([]entities.RecipeAncestor, error) {
+	rows, err := r.Pool.Query(ctx,
+		"WITH RECURSIVE lineage AS ("+
+			" SELECT forked_from AS id, 1 AS depth FROM recipes WHERE id=$1"+
+			" UNION ALL"+
+			" SELECT recipes.forked_from, lineage.depth + 1 FROM recipes JOIN lineage ON recipes.id = lineage.id"+
+			" WHERE lineage.depth < 100)"+
+			" SELECT recipes.id, recipes.title, users.login FROM lineage"+
+			" JOIN recipes ON recipes.id = lineage.id JOIN users ON users.id = recipes.user_id"+
+			" WHERE recipes.deleted_at IS NULL AND (recipes.status=$2 OR recipes.user_id=$3)"+
+			" ORDER BY lineage.depth",
+		recipeID, entities.StatusPublished, viewerID)
+	if err != nil {
+		return nil, fmt.Errorf("RecipeRepo - GetLineage - r.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	lineage := make([]entities.RecipeAncestor, 0)
+	for rows.Next() {
+		var ancestor entities.RecipeAncestor
+		err := rows.Scan(&ancestor.ID, &ancestor.Title, &ancestor.Author)
+		if err != nil {
+			return nil, fmt.Errorf("RecipeRepo - GetLineage - rows.Scan: %w", err)
+		}
+		lineage = append(lineage, ancestor)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("RecipeRepo - GetLineage - rows.Err: %w", err)
+	}
+	return lineage, nil
+}
+
+// UnusedPhotos filters out the photos which are still used by some recipe,
+// forks share photos with the recipes they were copied from.
+func (r *RecipeRepo) UnusedPhotos(ctx context.Context, urls []string) ([]string, error) {
+	unused, err := unusedPhotos(ctx, r.Pool, urls)
+	if err != nil {
+		return nil, fmt.Errorf("RecipeRepo - UnusedPhotos - unusedPhotos: %w", err)
+	}
+	return unused, nil
+}
+
+func unusedPhotos(ctx context.Context, q querier, urls []string) ([]string, error) {
+	rows, err := q.Query(ctx, "SELECT url FROM unnest($1::text[]) AS url WHERE url <> ''"+
+		" AND NOT EXISTS (SELECT 1 FROM recipes WHERE url = ANY(string_to_array(recipes.photos_urls, ';')))"+
+		" AND NOT EXISTS (SELECT 1 FROM recipe_steps WHERE recipe_steps.photo_url = url)", urls)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
@@ -0,0 +1,114 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Homyakadze14/RecipeSite/internal/entities"
+	"github.com/jackc/pgx/v5"
+)
+
+func (r *RecipeRepo) saveIngredients(ctx context.Context, tx pgx.Tx, recipeID int, ingredients []entities.Ingredient) error {
+	_, err := tx.Exec(ctx, "DELETE FROM ingredients WHERE recipe_id=$1", recipeID)
+	if err != nil {
+		return fmt.Errorf("RecipeRepo - saveIngredients - tx.Exec: %w", err)
+	}
+
+	batch := &pgx.Batch{}
+	for i, ingredient := range ingredients {
+		batch.Queue("INSERT INTO ingredients(recipe_id, position, name, quantity, unit, note) VALUES ($1,$2,$3,$4,$5,$6)",
+			recipeID, i+1, ingredient.Name, ingredient.Quantity, ingredient.Unit, ingredient.Note)
+	}
+
+	err = tx.SendBatch(ctx, batch).Close()
+	if err != nil {
+		return fmt.Errorf("RecipeRepo - saveIngredients - tx.SendBatch: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RecipeRepo) getIngredients(ctx context.Context, recipeID int) ([]entities.Ingredient, error) {
+	rows, err := r.Pool.Query(ctx, "SELECT name, quantity, unit, note FROM ingredients WHERE recipe_id=$1 ORDER BY position", recipeID)
+	if err != nil {
+		return nil, fmt.Errorf("RecipeRepo - getIngredients - r.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	ingredients := make([]entities.Ingredient, 0, constArraySize)
+	for rows.Next() {
+		var ingredient entities.Ingredient
+		err = rows.Scan(&ingredient.Name, &ingredient.Quantity, &ingredient.Unit, &ingredient.Note)
+		if err != nil {
+			return nil, fmt.Errorf("RecipeRepo - getIngredients - rows.Scan: %w", err)
+		}
+		ingredients = append(ingredients, ingredient)
+	}
+
+	return ingredients, nil
+}
+
+// ingredientWord matches the name as a whole word of an ingredient, in the
+// singular or the plural, so "salt" matches "sea salt" but not "unsalted butter".
+func ingredientWord(name string) string {
+	return `\m` + regexp.QuoteMeta(name) + `(s|es)?\M`
+}
+
+// GetByIngredients ranks the recipes the viewer may see by the share of their
+// ingredients which are at hand. An ingredient is at hand when one of the
+// given names is a word of its name. Recipes with fewer missing ingredients go first.
+func (r *RecipeRepo) GetByIngredients(ctx context.Context, have []string, params *entities.IngredientSearch) ([]entities.RecipeMatch, error) {
+	words := make([]string, 0, len(have))
+	for _, name := range have {
+		name = strings.TrimSpace(name)
+		if name != "" {
+			words = append(words, ingredientWord(name))
+		}
+	}
+
+	maxMissing := -1
+	if params.MaxMissing != nil {
+		maxMissing = *params.MaxMissing
+	}
+
+	rows, err := r.Pool.Query(ctx, "WITH visible AS ("+
+		"SELECT id FROM recipes WHERE deleted_at IS NULL AND (status=$2 OR user_id=$3)),"+
+		" coverage AS ("+
+		"SELECT ingredients.recipe_id, count(*) AS total, count(*) FILTER (WHERE at_hand.found) AS matched,"+
+		" array_agg(ingredients.name ORDER BY ingredients.position) FILTER (WHERE NOT at_hand.found) AS missing"+
+		" FROM visible JOIN ingredients ON ingredients.recipe_id = visible.id"+
+		" CROSS JOIN LATERAL (SELECT EXISTS (SELECT 1 FROM unnest($1::text[]) word"+
+		" WHERE ingredients.name ~* word) AS found) at_hand"+
+		" GROUP BY ingredients.recipe_id)"+
+		" SELECT "+recipeFields+", users.login, users.icon_url, coverage.matched, coverage.total, COALESCE(coverage.missing, '{}')"+
+		" FROM coverage JOIN recipes ON recipes.id = coverage.recipe_id JOIN users ON users.id = recipes.user_id"+
+		" WHERE coverage.matched > 0 AND ($4 < 0 OR coverage.total - coverage.matched <= $4)"+
+		" ORDER BY coverage.total - coverage.matched, coverage.matched DESC, recipes.id DESC LIMIT $5 OFFSET $6",
+		words, entities.StatusPublished, params.ViewerID, maxMissing, params.Limit, params.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("RecipeRepo - GetByIngredients - r.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	matches := make([]entities.RecipeMatch, 0, constArraySize)
+	for rows.Next() {
+		var recipe entities.Recipe
+		var match entities.RecipeMatch
+		author := &entities.Author{}
+		err := scanRecipe(rows, &recipe, &author.Login, &author.IconURL, &match.Matched, &match.Total, &match.Missing)
+		if err != nil {
+			return nil, fmt.Errorf("RecipeRepo - GetByIngredients - rows.Scan: %w", err)
+		}
+
+		match.Recipe = recipe.ToRecipeWithAuthor()
+		match.Recipe.Author = author
+		matches = append(matches, match)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("RecipeRepo - GetByIngredients - rows.Err: %w", err)
+	}
+
+	return matches, nil
+}
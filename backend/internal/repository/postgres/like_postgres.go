@@ -35,8 +35,10 @@ func (l *LikeRepo) IsAlreadyLike(ctx context.Context, like *entities.Like) (bool
 	return true, nil
 }
 
+// LikesCount counts the likes of the recipe, likes of users in the trash are left out.
 func (l *LikeRepo) LikesCount(ctx context.Context, recipeID int) (int, error) {
-	row := l.Pool.QueryRow(ctx, "SELECT COUNT(*) FROM likes WHERE recipe_id=$1", recipeID)
+	row := l.Pool.QueryRow(ctx, "SELECT COUNT(*) FROM likes JOIN users ON users.id = likes.user_id"+
+		" WHERE likes.recipe_id=$1 AND users.deleted_at IS NULL", recipeID)
 
 	var count int
 	err := row.Scan(&count)
@@ -48,13 +50,18 @@ func (l *LikeRepo) LikesCount(ctx context.Context, recipeID int) (int, error) {
 }
 
 func (l *LikeRepo) Like(ctx context.Context, like *entities.Like) error {
-	_, err := l.Pool.Exec(ctx, "INSERT INTO likes(user_id, recipe_id) VALUES ($1, $2)", like.UserID, like.RecipeID)
+	res, err := l.Pool.Exec(ctx, "INSERT INTO likes(user_id, recipe_id) SELECT $1, $2"+
+		" WHERE EXISTS (SELECT 1 FROM recipes WHERE id=$2 AND deleted_at IS NULL AND (status=$3 OR user_id=$1))",
+		like.UserID, like.RecipeID, entities.StatusPublished)
 	if err != nil {
 		if strings.Contains(err.Error(), "SQLSTATE 23503") {
 			return usecases.ErrRecipeNotFound
 		}
 		return fmt.Errorf("LikeRepo - Like - r.Pool.Exec: %w", err)
 	}
+	if res.RowsAffected() == 0 {
+		return usecases.ErrRecipeNotFound
+	}
 	return nil
 }
 
@@ -71,8 +78,9 @@ func (l *LikeRepo) Unlike(ctx context.Context, like *entities.Like) error {
 
 func (l *LikeRepo) GetLikedRecipies(ctx context.Context, userID int) ([]entities.Recipe, error) {
 	rows, err := l.Pool.Query(ctx,
-		"SELECT recipes.id, recipes.user_id, title, about, complexitiy, need_time, ingridients, instructions, photos_urls, created_at, updated_at FROM likes JOIN recipes ON recipes.id=likes.recipe_id WHERE likes.user_id=$1",
-		userID)
+		"SELECT "+recipeFields+" FROM likes JOIN recipes ON recipes.id=likes.recipe_id"+
+			" WHERE likes.user_id=$1 AND (recipes.status=$2 OR recipes.user_id=$1) AND recipes.deleted_at IS NULL",
+		userID, entities.StatusPublished)
 
 	if err != nil {
 		return nil, fmt.Errorf("LikeRepo - GetLikedRecipies - r.Pool.Query: %w", err)
@@ -81,9 +89,7 @@ func (l *LikeRepo) GetLikedRecipies(ctx context.Context, userID int) ([]entities
 	recipes := make([]entities.Recipe, 0, 20)
 	for rows.Next() {
 		var recipe entities.Recipe
-		err := rows.Scan(&recipe.ID, &recipe.UserID, &recipe.Title, &recipe.About,
-			&recipe.Complexitiy, &recipe.NeedTime, &recipe.Ingridients, &recipe.Instructions,
-			&recipe.PhotosUrls, &recipe.CreatedAt, &recipe.UpdatedAt)
+		err := scanRecipe(rows, &recipe)
 		if err != nil {
 			return nil, fmt.Errorf("LikeRepo - GetLikedRecipies - rows.Scan: %w", err)
 		}
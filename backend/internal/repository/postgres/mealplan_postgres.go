@@ -0,0 +1,138 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Homyakadze14/RecipeSite/internal/entities"
+	"github.com/Homyakadze14/RecipeSite/internal/usecases"
+	"github.com/Homyakadze14/RecipeSite/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+)
+
+type MealPlanRepo struct {
+	*postgres.Postgres
+}
+
+func NewMealPlanRepository(pg *postgres.Postgres) *MealPlanRepo {
+	return &MealPlanRepo{pg}
+}
+
+// Get gets the entries of the user from from to to inclusive. Entries of
+// recipes the user can't see anymore are left out.
+func (r *MealPlanRepo) Get(ctx context.Context, userID int, from, to time.Time) ([]entities.MealPlanEntry, error) {
+	rows, err := r.Pool.Query(ctx, "SELECT "+recipeFields+", users.login, users.icon_url,"+
+		" meal_plan_entries.id, meal_plan_entries.date, meal_plan_entries.slot, meal_plan_entries.servings"+
+		" FROM meal_plan_entries JOIN recipes ON recipes.id = meal_plan_entries.recipe_id JOIN users ON users.id = recipes.user_id"+
+		" WHERE meal_plan_entries.user_id=$1 AND meal_plan_entries.date BETWEEN $2 AND $3"+
+		" AND recipes.deleted_at IS NULL AND (recipes.status=$4 OR recipes.user_id=$1)"+
+		" ORDER BY meal_plan_entries.date, meal_plan_entries.id",
+		userID, from, to, entities.StatusPublished)
+	if err != nil {
+		return nil, fmt.Errorf("MealPlanRepo - Get - r.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]entities.MealPlanEntry, 0, constArraySize)
+	for rows.Next() {
+		var recipe entities.Recipe
+		var entry entities.MealPlanEntry
+		var date time.Time
+		author := &entities.Author{}
+		err := scanRecipe(rows, &recipe, &author.Login, &author.IconURL, &entry.ID, &date, &entry.Slot, &entry.Servings)
+		if err != nil {
+			return nil, fmt.Errorf("MealPlanRepo - Get - rows.Scan: %w", err)
+		}
+
+		entry.Date = date.Format(entities.DateLayout)
+		entry.Recipe = recipe.ToRecipeWithAuthor()
+		entry.Recipe.Author = author
+		entries = append(entries, entry)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("MealPlanRepo - Get - rows.Err: %w", err)
+	}
+
+	return entries, nil
+}
+
+func (r *MealPlanRepo) GetEntry(ctx context.Context, userID, id int) (*entities.MealPlanEntry, error) {
+	row := r.Pool.QueryRow(ctx, "SELECT id, date, slot, servings FROM meal_plan_entries WHERE id=$1 AND user_id=$2", id, userID)
+
+	entry := &entities.MealPlanEntry{}
+	var date time.Time
+	err := row.Scan(&entry.ID, &date, &entry.Slot, &entry.Servings)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, usecases.ErrMealPlanEntryNotFound
+		}
+		return nil, fmt.Errorf("MealPlanRepo - GetEntry - row.Scan: %w", err)
+	}
+	entry.Date = date.Format(entities.DateLayout)
+	return entry, nil
+}
+
+// Add plans the recipe if the user may see it. Without servings the
+// servings of the recipe are planned.
+func (r *MealPlanRepo) Add(ctx context.Context, userID int, date time.Time, params *entities.AddMealPlanEntry) (id int, err error) {
+	row := r.Pool.QueryRow(ctx, "INSERT INTO meal_plan_entries(user_id, recipe_id, date, slot, servings, created_at)"+
+		" SELECT $1, id, $3, $4, CASE WHEN $5 > 0 THEN $5 ELSE GREATEST(servings, 1) END, $6 FROM recipes"+
+		" WHERE id=$2 AND deleted_at IS NULL AND (status=$7 OR user_id=$1) RETURNING id",
+		userID, params.RecipeID, date, params.Slot, params.Servings, time.Now(), entities.StatusPublished)
+
+	err = row.Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return -1, usecases.ErrRecipeNotFound
+		}
+		if strings.Contains(err.Error(), "SQLSTATE 23505") {
+			return -1, usecases.ErrMealAlreadyPlanned
+		}
+		return -1, fmt.Errorf("MealPlanRepo - Add - row.Scan: %w", err)
+	}
+	return id, nil
+}
+
+func (r *MealPlanRepo) Update(ctx context.Context, userID int, date time.Time, entry *entities.MealPlanEntry) error {
+	_, err := r.Pool.Exec(ctx, "UPDATE meal_plan_entries SET date=$1, slot=$2, servings=$3 WHERE id=$4 AND user_id=$5",
+		date, entry.Slot, entry.Servings, entry.ID, userID)
+	if err != nil {
+		if strings.Contains(err.Error(), "SQLSTATE 23505") {
+			return usecases.ErrMealAlreadyPlanned
+		}
+		return fmt.Errorf("MealPlanRepo - Update - r.Pool.Exec: %w", err)
+	}
+	return nil
+}
+
+func (r *MealPlanRepo) Delete(ctx context.Context, userID, id int) error {
+	res, err := r.Pool.Exec(ctx, "DELETE FROM meal_plan_entries WHERE id=$1 AND user_id=$2", id, userID)
+	if err != nil {
+		return fmt.Errorf("MealPlanRepo - Delete - r.Pool.Exec: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return usecases.ErrMealPlanEntryNotFound
+	}
+	return nil
+}
+
+// CopyWeek copies the seven days starting at from to the days starting at to.
+// Meals which are already planned in the target week are skipped, and so are
+// recipes the user may no longer see.
+func (r *MealPlanRepo) CopyWeek(ctx context.Context, userID int, from, to time.Time) (copied int, err error) {
+	res, err := r.Pool.Exec(ctx, "INSERT INTO meal_plan_entries(user_id, recipe_id, date, slot, servings, created_at)"+
+		" SELECT meal_plan_entries.user_id, meal_plan_entries.recipe_id, meal_plan_entries.date + ($3::date - $2::date),"+
+		" meal_plan_entries.slot, meal_plan_entries.servings, $4 FROM meal_plan_entries"+
+		" JOIN recipes ON recipes.id = meal_plan_entries.recipe_id"+
+		" WHERE meal_plan_entries.user_id=$1 AND meal_plan_entries.date >= $2 AND meal_plan_entries.date < $2::date + 7"+
+		" AND recipes.deleted_at IS NULL AND (recipes.status=$5 OR recipes.user_id=$1)"+
+		" ON CONFLICT (user_id, date, slot, recipe_id) DO NOTHING",
+		userID, from, to, time.Now(), entities.StatusPublished)
+	if err != nil {
+		return 0, fmt.Errorf("MealPlanRepo - CopyWeek - r.Pool.Exec: %w", err)
+	}
+	return int(res.RowsAffected()), nil
+}
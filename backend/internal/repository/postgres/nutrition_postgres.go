@@ -0,0 +1,41 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Homyakadze14/RecipeSite/internal/entities"
+	"github.com/Homyakadze14/RecipeSite/pkg/postgres"
+)
+
+type NutritionRepo struct {
+	*postgres.Postgres
+}
+
+func NewNutritionRepository(pg *postgres.Postgres) *NutritionRepo {
+	return &NutritionRepo{pg}
+}
+
+func (r *NutritionRepo) GetFoods(ctx context.Context) ([]entities.Food, error) {
+	rows, err := r.Pool.Query(ctx, "SELECT name, keywords, calories, protein, fat, carbs, piece_weight FROM nutrition_foods ORDER BY id")
+	if err != nil {
+		return nil, fmt.Errorf("NutritionRepo - GetFoods - r.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	foods := make([]entities.Food, 0, constArraySize)
+	for rows.Next() {
+		var food entities.Food
+		err := rows.Scan(&food.Name, &food.Keywords, &food.Facts.Calories, &food.Facts.Protein,
+			&food.Facts.Fat, &food.Facts.Carbs, &food.PieceWeight)
+		if err != nil {
+			return nil, fmt.Errorf("NutritionRepo - GetFoods - rows.Scan: %w", err)
+		}
+		foods = append(foods, food)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("NutritionRepo - GetFoods - rows.Err: %w", err)
+	}
+
+	return foods, nil
+}
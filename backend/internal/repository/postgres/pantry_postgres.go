@@ -0,0 +1,71 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Homyakadze14/RecipeSite/internal/entities"
+	"github.com/Homyakadze14/RecipeSite/internal/usecases"
+	"github.com/Homyakadze14/RecipeSite/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+)
+
+type PantryRepo struct {
+	*postgres.Postgres
+}
+
+func NewPantryRepository(pg *postgres.Postgres) *PantryRepo {
+	return &PantryRepo{pg}
+}
+
+func (r *PantryRepo) GetAll(ctx context.Context, userID int) ([]entities.PantryItem, error) {
+	rows, err := r.Pool.Query(ctx, "SELECT id, name FROM pantry_items WHERE user_id=$1 ORDER BY lower(name)", userID)
+	if err != nil {
+		return nil, fmt.Errorf("PantryRepo - GetAll - r.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	items := make([]entities.PantryItem, 0, constArraySize)
+	for rows.Next() {
+		var item entities.PantryItem
+		err = rows.Scan(&item.ID, &item.Name)
+		if err != nil {
+			return nil, fmt.Errorf("PantryRepo - GetAll - rows.Scan: %w", err)
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// Add puts the items into the pantry, the ones which are there already are skipped.
+func (r *PantryRepo) Add(ctx context.Context, userID int, names []string) (added int, err error) {
+	batch := &pgx.Batch{}
+	for _, name := range names {
+		batch.Queue("INSERT INTO pantry_items(user_id, name) VALUES ($1,$2) ON CONFLICT (user_id, lower(name)) DO NOTHING",
+			userID, name)
+	}
+
+	results := r.Pool.SendBatch(ctx, batch)
+	defer results.Close()
+
+	for range names {
+		res, err := results.Exec()
+		if err != nil {
+			return 0, fmt.Errorf("PantryRepo - Add - results.Exec: %w", err)
+		}
+		added += int(res.RowsAffected())
+	}
+	return added, nil
+}
+
+func (r *PantryRepo) Delete(ctx context.Context, userID, id int) error {
+	res, err := r.Pool.Exec(ctx, "DELETE FROM pantry_items WHERE id=$1 AND user_id=$2", id, userID)
+	if err != nil {
+		return fmt.Errorf("PantryRepo - Delete - r.Pool.Exec: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return usecases.ErrPantryItemNotFound
+	}
+	return nil
+}
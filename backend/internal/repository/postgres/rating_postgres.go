@@ -0,0 +1,146 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Homyakadze14/RecipeSite/internal/entities"
+	"github.com/Homyakadze14/RecipeSite/internal/usecases"
+	"github.com/Homyakadze14/RecipeSite/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	ratingFields      = "id, user_id, recipe_id, rating, review, created_at, updated_at"
+	ratingCursorField = "rating"
+)
+
+type RatingRepo struct {
+	*postgres.Postgres
+}
+
+func NewRatingRepository(pg *postgres.Postgres) *RatingRepo {
+	return &RatingRepo{pg}
+}
+
+func scanRating(row pgx.Row, rating *entities.Rating) error {
+	return row.Scan(&rating.ID, &rating.UserID, &rating.RecipeID, &rating.Rating, &rating.Review,
+		&rating.CreatedAt, &rating.UpdatedAt)
+}
+
+func (r *RatingRepo) Get(ctx context.Context, userID, recipeID int) (*entities.Rating, error) {
+	row := r.Pool.QueryRow(ctx, "SELECT "+ratingFields+" FROM ratings WHERE user_id=$1 AND recipe_id=$2", userID, recipeID)
+
+	rating := &entities.Rating{}
+	err := scanRating(row, rating)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, usecases.ErrRatingNotFound
+		}
+		return nil, fmt.Errorf("RatingRepo - Get - row.Scan: %w", err)
+	}
+	return rating, nil
+}
+
+// GetAll gets a page of the ratings of the recipe with their authors, the
+// latest first. Ratings of deleted users are left out.
+func (r *RatingRepo) GetAll(ctx context.Context, recipeID int, page *entities.RatingPage) ([]entities.Rating, error) {
+	var request strings.Builder
+	builder := &queryBuilder{params: make([]interface{}, 0, 4)}
+
+	if page.Cursor != "" {
+		after, err := decodeCursor(page.Cursor)
+		if err != nil || after.Field != ratingCursorField {
+			return nil, usecases.ErrBadCursor
+		}
+		builder.where(fmt.Sprintf("(ratings.updated_at, ratings.id) < (%s::timestamp, %s)",
+			builder.arg(after.Value), builder.arg(after.ID)))
+	}
+
+	builder.where("ratings.recipe_id = " + builder.arg(recipeID))
+	builder.where("users.deleted_at IS NULL")
+	request.WriteString("SELECT ratings.id, ratings.user_id, ratings.recipe_id, ratings.rating, ratings.review," +
+		" ratings.created_at, ratings.updated_at, ratings.updated_at::text, users.login, users.icon_url" +
+		" FROM ratings JOIN users ON users.id = ratings.user_id")
+	request.WriteString(builder.whereClause())
+	request.WriteString(" ORDER BY ratings.updated_at DESC, ratings.id DESC LIMIT " + builder.arg(page.Limit))
+
+	rows, err := r.Pool.Query(ctx, request.String(), builder.params...)
+	if err != nil {
+		return nil, fmt.Errorf("RatingRepo - GetAll - r.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	ratings := make([]entities.Rating, 0, page.Limit)
+	for rows.Next() {
+		var rating entities.Rating
+		var updatedAt string
+		author := &entities.Author{}
+		err = rows.Scan(&rating.ID, &rating.UserID, &rating.RecipeID, &rating.Rating, &rating.Review,
+			&rating.CreatedAt, &rating.UpdatedAt, &updatedAt, &author.Login, &author.IconURL)
+		if err != nil {
+			return nil, fmt.Errorf("RatingRepo - GetAll - rows.Scan: %w", err)
+		}
+
+		rating.Author = author
+		rating.Cursor = (&cursor{Field: ratingCursorField, Value: updatedAt, ID: rating.ID}).encode()
+		ratings = append(ratings, rating)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("RatingRepo - GetAll - rows.Err: %w", err)
+	}
+
+	return ratings, nil
+}
+
+func (r *RatingRepo) Summary(ctx context.Context, recipeID int) (*entities.RatingSummary, error) {
+	rows, err := r.Pool.Query(ctx, "SELECT ratings.rating, count(*) FROM ratings JOIN users ON users.id = ratings.user_id"+
+		" WHERE ratings.recipe_id=$1 AND users.deleted_at IS NULL GROUP BY ratings.rating", recipeID)
+	if err != nil {
+		return nil, fmt.Errorf("RatingRepo - Summary - r.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[int]int, entities.MaxRating)
+	for rows.Next() {
+		var stars, count int
+		err = rows.Scan(&stars, &count)
+		if err != nil {
+			return nil, fmt.Errorf("RatingRepo - Summary - rows.Scan: %w", err)
+		}
+		counts[stars] = count
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("RatingRepo - Summary - rows.Err: %w", err)
+	}
+
+	return entities.NewRatingSummary(counts), nil
+}
+
+// Rate saves the rating of the user or replaces the previous one.
+func (r *RatingRepo) Rate(ctx context.Context, rating *entities.Rating) (id int, err error) {
+	row := r.Pool.QueryRow(ctx, "INSERT INTO ratings(user_id, recipe_id, rating, review, created_at, updated_at)"+
+		" VALUES ($1,$2,$3,$4,$5,$5) ON CONFLICT (user_id, recipe_id)"+
+		" DO UPDATE SET rating=EXCLUDED.rating, review=EXCLUDED.review, updated_at=EXCLUDED.updated_at RETURNING id",
+		rating.UserID, rating.RecipeID, rating.Rating, rating.Review, time.Now())
+
+	err = row.Scan(&id)
+	if err != nil {
+		return -1, fmt.Errorf("RatingRepo - Rate - row.Scan: %w", err)
+	}
+	return id, nil
+}
+
+func (r *RatingRepo) Delete(ctx context.Context, userID, recipeID int) error {
+	res, err := r.Pool.Exec(ctx, "DELETE FROM ratings WHERE user_id=$1 AND recipe_id=$2", userID, recipeID)
+	if err != nil {
+		return fmt.Errorf("RatingRepo - Delete - r.Pool.Exec: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return usecases.ErrRatingNotFound
+	}
+	return nil
+}
@@ -19,8 +19,55 @@ const (
 	orderByDesc = 1
 )
 
+const (
+	orderFieldRelevance = "relevance"
+	snippetOptions      = "StartSel=<b>, StopSel=</b>, MaxFragments=2, MaxWords=25, MinWords=10"
+)
+
 var constArraySize = 20
 
+const recipeFields = "recipes.id, recipes.user_id, recipes.title, recipes.about, recipes.complexitiy, " +
+	"recipes.prep_minutes, recipes.cook_minutes, recipes.total_minutes, " +
+	"recipes.ingridients, recipes.instructions, recipes.photos_urls, recipes.created_at, recipes.updated_at, recipes.servings, " +
+	"recipes.allergens, recipes.diets, recipes.status, recipes.publish_at, recipes.published_at, recipes.forked_from"
+
+// scanRecipe scans a row selected with recipeFields followed by the extra columns.
+func scanRecipe(row pgx.Row, recipe *entities.Recipe, extra ...any) error {
+	dest := []any{&recipe.ID, &recipe.UserID, &recipe.Title, &recipe.About,
+		&recipe.Complexitiy, &recipe.PrepMinutes, &recipe.CookMinutes, &recipe.TotalMinutes, &recipe.Ingridients, &recipe.Instructions,
+		&recipe.PhotosUrls, &recipe.CreatedAt, &recipe.UpdatedAt, &recipe.Servings,
+		&recipe.Allergens, &recipe.Diets, &recipe.Status, &recipe.PublishAt, &recipe.PublishedAt, &recipe.ForkedFrom}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return err
+	}
+	recipe.SetTotalMinutes(recipe.TotalMinutes)
+	return nil
+}
+
+// ratingAverage is the average rating of the recipe. Recipes which aren't
+// rated yet count as rated zero, ratings of deleted users don't count.
+const ratingAverage = "(SELECT COALESCE(avg(ratings.rating), 0) FROM ratings JOIN users ON users.id = ratings.user_id" +
+	" WHERE ratings.recipe_id = recipes.id AND users.deleted_at IS NULL)"
+
+// orderKeys are the expressions recipes can be ordered by and the types
+// their text representation in a cursor is cast back to.
+var orderKeys = map[string]struct{ expr, typ string }{
+	"title":             {"recipes.title", "text"},
+	"complexitiy":       {"recipes.complexitiy", "int"},
+	"updated_at":        {"recipes.updated_at", "timestamp"},
+	"need_time":         {"recipes.total_minutes", "int"},
+	orderFieldRelevance: {"ts_rank(recipes.search_vector, query)", "real"},
+	"rating":            {ratingAverage, "numeric"},
+}
+
+// labels turns nil into an empty array, the label columns are NOT NULL.
+func labels(l []string) []string {
+	if l == nil {
+		return []string{}
+	}
+	return l
+}
+
 type RecipeRepo struct {
 	*postgres.Postgres
 }
@@ -29,76 +76,129 @@ func NewRecipeRepository(pg *postgres.Postgres) *RecipeRepo {
 	return &RecipeRepo{pg}
 }
 
-func (r *RecipeRepo) GetAll(ctx context.Context) ([]entities.Recipe, error) {
-	rows, err := r.Pool.Query(ctx, "SELECT * FROM recipes")
+func (r *RecipeRepo) GetAll(ctx context.Context, page *entities.RecipePage) ([]entities.RecipeWithAuthor, error) {
+	var request strings.Builder
+	builder := &queryBuilder{params: make([]interface{}, 0, 3)}
+
+	if page.Cursor != "" {
+		after, err := decodeCursor(page.Cursor)
+		if err != nil || after.Field != "id" {
+			return nil, usecases.ErrBadCursor
+		}
+		builder.where("recipes.id < " + builder.arg(after.ID))
+	}
+
+	builder.where("recipes.status = " + builder.arg(entities.StatusPublished))
+	builder.where("recipes.deleted_at IS NULL")
+	request.WriteString("SELECT " + recipeFields + ", users.login, users.icon_url FROM recipes JOIN users ON users.id = recipes.user_id")
+	request.WriteString(builder.whereClause())
+	request.WriteString(" ORDER BY recipes.id DESC LIMIT " + builder.arg(page.Limit))
 
+	rows, err := r.Pool.Query(ctx, request.String(), builder.params...)
 	if err != nil {
 		return nil, fmt.Errorf("RecipeRepo - GetAll - r.Pool.Query: %w", err)
 	}
+	defer rows.Close()
 
-	recipes := make([]entities.Recipe, 0, constArraySize)
+	recipes := make([]entities.RecipeWithAuthor, 0, page.Limit)
 	for rows.Next() {
 		var recipe entities.Recipe
-		err := rows.Scan(&recipe.ID, &recipe.UserID, &recipe.Title, &recipe.About,
-			&recipe.Complexitiy, &recipe.NeedTime, &recipe.Ingridients, &recipe.Instructions,
-			&recipe.PhotosUrls, &recipe.CreatedAt, &recipe.UpdatedAt)
+		author := &entities.Author{}
+		err := scanRecipe(rows, &recipe, &author.Login, &author.IconURL)
 		if err != nil {
 			return nil, fmt.Errorf("RecipeRepo - GetAll - rows.Scan: %w", err)
 		}
-		recipes = append(recipes, recipe)
+
+		rwa := recipe.ToRecipeWithAuthor()
+		rwa.Author = author
+		rwa.Cursor = (&cursor{Field: "id", ID: recipe.ID}).encode()
+		recipes = append(recipes, *rwa)
 	}
 
 	return recipes, nil
 }
 
+func (r *RecipeRepo) Count(ctx context.Context) (count int, err error) {
+	err = r.Pool.QueryRow(ctx, "SELECT count(*) FROM recipes WHERE status=$1 AND deleted_at IS NULL", entities.StatusPublished).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("RecipeRepo - Count - row.Scan: %w", err)
+	}
+	return count, nil
+}
+
 func (r *RecipeRepo) GetFiltered(ctx context.Context, filter *entities.RecipeFilter) ([]entities.Recipe, error) {
 	var request strings.Builder
-	params := make([]interface{}, 0, 5)
+	builder := &queryBuilder{params: make([]interface{}, 0, 5)}
+
+	allowOrderFields := []string{"", "title", "complexitiy", "updated_at", "need_time", orderFieldRelevance, "rating"}
+	if !slices.Contains(allowOrderFields, filter.OrderField) {
+		return nil, usecases.ErrBadOrderField
+	}
 
-	params = append(params, filter.Query)
-	request.WriteString("SELECT * FROM recipes WHERE title LIKE '%'||$1||'%' OR about LIKE '%'||$1||'%' OR ingridients LIKE '%'||$1||'%' OR instructions LIKE '%'||$1||'%'")
+	orderField := filter.OrderField
+	if orderField == "" || (orderField == orderFieldRelevance && filter.Query == "") {
+		orderField = "updated_at"
+	}
+	orderKey := orderKeys[orderField]
 
-	allowOrderFields := []string{"", "title", "complexitiy", "updated_at"}
-	if slices.Contains(allowOrderFields, filter.OrderField) {
-		if filter.OrderField == "" {
-			filter.OrderField = "updated_at"
-		}
-		request.WriteString(fmt.Sprintf(" ORDER BY %s", filter.OrderField))
+	// The most relevant recipes go first unless asked otherwise.
+	desc := filter.OrderBy == orderByDesc || (orderField == orderFieldRelevance && filter.OrderBy != orderByAsc)
+
+	if filter.Query != "" {
+		builder.where("recipes.search_vector @@ query")
+		request.WriteString("SELECT " + recipeFields + ", ts_headline('russian', concat_ws(' ', recipes.about, recipes.ingridients, recipes.instructions), query, '" + snippetOptions + "')" +
+			", (" + orderKey.expr + ")::text FROM recipes, websearch_to_tsquery('russian', " + builder.arg(filter.Query) + ") query")
 	} else {
-		return nil, usecases.ErrBadOrderField
+		request.WriteString("SELECT " + recipeFields + ", '', (" + orderKey.expr + ")::text FROM recipes")
+	}
+
+	applyRecipeFilter(builder, filter)
+
+	if filter.Cursor != "" {
+		after, err := decodeCursor(filter.Cursor)
+		if err != nil || after.Field != orderField {
+			return nil, usecases.ErrBadCursor
+		}
+
+		comparison := ">"
+		if desc {
+			comparison = "<"
+		}
+		builder.where(fmt.Sprintf("(%s, recipes.id) %s (%s::%s, %s)", orderKey.expr, comparison,
+			builder.arg(after.Value), orderKey.typ, builder.arg(after.ID)))
 	}
 
-	switch filter.OrderBy {
-	case orderByAsc:
-		request.WriteString(" ASC")
-	case orderByDesc:
-		request.WriteString(" DESC")
+	request.WriteString(builder.whereClause())
+
+	direction := " ASC"
+	if desc {
+		direction = " DESC"
 	}
+	request.WriteString(" ORDER BY " + orderKey.expr + direction + ", recipes.id" + direction)
 
 	if filter.Limit != 0 {
-		params = append(params, filter.Limit)
-		request.WriteString(fmt.Sprintf(" LIMIT $%v", len(params)))
+		request.WriteString(" LIMIT " + builder.arg(filter.Limit))
 	}
 
 	if filter.Offset != 0 {
-		params = append(params, filter.Offset)
-		request.WriteString(fmt.Sprintf(" OFFSET $%v", len(params)))
+		request.WriteString(" OFFSET " + builder.arg(filter.Offset))
 	}
 
-	rows, err := r.Pool.Query(ctx, request.String(), params...)
+	rows, err := r.Pool.Query(ctx, request.String(), builder.params...)
 	if err != nil {
 		return nil, fmt.Errorf("RecipeRepo - GetFiltered - r.Pool.Query: %w", err)
 	}
+	defer rows.Close()
 
 	recipes := make([]entities.Recipe, 0, constArraySize)
 	for rows.Next() {
 		var recipe entities.Recipe
-		err := rows.Scan(&recipe.ID, &recipe.UserID, &recipe.Title, &recipe.About,
-			&recipe.Complexitiy, &recipe.NeedTime, &recipe.Ingridients, &recipe.Instructions,
-			&recipe.PhotosUrls, &recipe.CreatedAt, &recipe.UpdatedAt)
+		var orderValue string
+		err := scanRecipe(rows, &recipe, &recipe.Snippet, &orderValue)
 		if err != nil {
 			return nil, fmt.Errorf("RecipeRepo - GetFiltered - rows.Scan: %w", err)
 		}
+		recipe.Cursor = (&cursor{Field: orderField, Value: orderValue, ID: recipe.ID}).encode()
 		recipes = append(recipes, recipe)
 	}
 
@@ -106,12 +206,10 @@ func (r *RecipeRepo) GetFiltered(ctx context.Context, filter *entities.RecipeFil
 }
 
 func (r *RecipeRepo) Get(ctx context.Context, id int) (*entities.Recipe, error) {
-	row := r.Pool.QueryRow(ctx, "SELECT * FROM recipes WHERE id=$1", id)
+	row := r.Pool.QueryRow(ctx, "SELECT "+recipeFields+" FROM recipes WHERE id=$1 AND deleted_at IS NULL", id)
 
 	recipe := &entities.Recipe{}
-	err := row.Scan(&recipe.ID, &recipe.UserID, &recipe.Title, &recipe.About,
-		&recipe.Complexitiy, &recipe.NeedTime, &recipe.Ingridients, &recipe.Instructions,
-		&recipe.PhotosUrls, &recipe.CreatedAt, &recipe.UpdatedAt)
+	err := scanRecipe(row, recipe)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -120,34 +218,150 @@ func (r *RecipeRepo) Get(ctx context.Context, id int) (*entities.Recipe, error)
 		return nil, fmt.Errorf("RecipeRepo - Get - row.Scan: %w", err)
 	}
 
+	recipe.Ingredients, err = r.getIngredients(ctx, recipe.ID)
+	if err != nil {
+		return nil, fmt.Errorf("RecipeRepo - Get - r.getIngredients: %w", err)
+	}
+
+	recipe.Steps, err = r.getSteps(ctx, recipe.ID)
+	if err != nil {
+		return nil, fmt.Errorf("RecipeRepo - Get - r.getSteps: %w", err)
+	}
+
+	recipe.Tags, err = r.getTags(ctx, recipe.ID)
+	if err != nil {
+		return nil, fmt.Errorf("RecipeRepo - Get - r.getTags: %w", err)
+	}
+
 	return recipe, nil
 }
 
 func (r *RecipeRepo) Save(ctx context.Context, recipe *entities.Recipe) (id int, err error) {
-	row := r.Pool.QueryRow(ctx, "INSERT INTO recipes(user_id,title,about,complexitiy,need_time,ingridients,instructions,photos_urls,created_at,updated_at) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10) RETURNING id",
-		recipe.UserID, recipe.Title, recipe.About, recipe.Complexitiy, recipe.NeedTime, recipe.Ingridients, recipe.Instructions, recipe.PhotosUrls, time.Now(), time.Now())
+	tx, err := r.Pool.Begin(ctx)
+	if err != nil {
+		return -1, fmt.Errorf("RecipeRepo - Create - r.Pool.Begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	row := tx.QueryRow(ctx, "INSERT INTO recipes(user_id,title,about,complexitiy,prep_minutes,cook_minutes,total_minutes,ingridients,instructions,photos_urls,created_at,updated_at,servings,allergens,diets,status,publish_at,published_at,forked_from) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19) RETURNING id",
+		recipe.UserID, recipe.Title, recipe.About, recipe.Complexitiy, recipe.PrepMinutes, recipe.CookMinutes, recipe.TotalMinutes,
+		recipe.Ingridients, recipe.Instructions, recipe.PhotosUrls, time.Now(), time.Now(), recipe.Servings,
+		labels(recipe.Allergens), labels(recipe.Diets), recipe.Status, recipe.PublishAt, recipe.PublishedAt, recipe.ForkedFrom)
 
 	err = row.Scan(&id)
 	if err != nil {
-		return -1, fmt.Errorf("RecipeRepo - Create - r.Pool.QueryRow: %w", err)
+		return -1, fmt.Errorf("RecipeRepo - Create - tx.QueryRow: %w", err)
+	}
+
+	err = r.saveIngredients(ctx, tx, id, recipe.Ingredients)
+	if err != nil {
+		return -1, fmt.Errorf("RecipeRepo - Create - r.saveIngredients: %w", err)
+	}
+
+	err = r.saveSteps(ctx, tx, id, recipe.Steps)
+	if err != nil {
+		return -1, fmt.Errorf("RecipeRepo - Create - r.saveSteps: %w", err)
+	}
+
+	err = r.saveTags(ctx, tx, id, recipe.Tags)
+	if err != nil {
+		return -1, fmt.Errorf("RecipeRepo - Create - r.saveTags: %w", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return -1, fmt.Errorf("RecipeRepo - Create - tx.Commit: %w", err)
 	}
 	return id, nil
 }
 
-func (r *RecipeRepo) Update(ctx context.Context, updatedRecipe *entities.Recipe) error {
-	_, err := r.Pool.Exec(ctx, "UPDATE recipes SET title=$1,about=$2,complexitiy=$3,need_time=$4,ingridients=$5,photos_urls=$6,updated_at=$7,instructions=$8 WHERE id=$9",
-		updatedRecipe.Title, updatedRecipe.About, updatedRecipe.Complexitiy, updatedRecipe.NeedTime,
-		updatedRecipe.Ingridients, updatedRecipe.PhotosUrls, time.Now(), updatedRecipe.Instructions, updatedRecipe.ID)
+// Update saves the recipe and keeps the previous version of it as a revision.
+func (r *RecipeRepo) Update(ctx context.Context, updatedRecipe, previous *entities.Recipe) error {
+	tx, err := r.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("RecipeRepo - Update - r.Pool.Begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	err = r.saveRevision(ctx, tx, previous)
+	if err != nil {
+		return fmt.Errorf("RecipeRepo - Update - r.saveRevision: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE recipes SET title=$1,about=$2,complexitiy=$3,prep_minutes=$4,cook_minutes=$5,total_minutes=$6,ingridients=$7,photos_urls=$8,updated_at=$9,instructions=$10,servings=$11,allergens=$12,diets=$13 WHERE id=$14",
+		updatedRecipe.Title, updatedRecipe.About, updatedRecipe.Complexitiy, updatedRecipe.PrepMinutes, updatedRecipe.CookMinutes, updatedRecipe.TotalMinutes,
+		updatedRecipe.Ingridients, updatedRecipe.PhotosUrls, time.Now(), updatedRecipe.Instructions, updatedRecipe.Servings,
+		labels(updatedRecipe.Allergens), labels(updatedRecipe.Diets), updatedRecipe.ID)
+
+	if err != nil {
+		return fmt.Errorf("RecipeRepo - Update - tx.Exec: %w", err)
+	}
 
+	err = r.saveIngredients(ctx, tx, updatedRecipe.ID, updatedRecipe.Ingredients)
 	if err != nil {
-		return fmt.Errorf("RecipeRepo - Update - r.Pool.Exec: %w", err)
+		return fmt.Errorf("RecipeRepo - Update - r.saveIngredients: %w", err)
 	}
 
+	err = r.saveSteps(ctx, tx, updatedRecipe.ID, updatedRecipe.Steps)
+	if err != nil {
+		return fmt.Errorf("RecipeRepo - Update - r.saveSteps: %w", err)
+	}
+
+	err = r.saveTags(ctx, tx, updatedRecipe.ID, updatedRecipe.Tags)
+	if err != nil {
+		return fmt.Errorf("RecipeRepo - Update - r.saveTags: %w", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("RecipeRepo - Update - tx.Commit: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RecipeRepo) SetStatus(ctx context.Context, recipe *entities.Recipe) error {
+	_, err := r.Pool.Exec(ctx, "UPDATE recipes SET status=$1,publish_at=$2,published_at=$3,updated_at=$4 WHERE id=$5",
+		recipe.Status, recipe.PublishAt, recipe.PublishedAt, time.Now(), recipe.ID)
+	if err != nil {
+		return fmt.Errorf("RecipeRepo - SetStatus - r.Pool.Exec: %w", err)
+	}
 	return nil
 }
 
+// PublishDue publishes up to limit scheduled recipes whose time has come.
+// Locked rows are skipped, so the recipe is published by one replica only.
+func (r *RecipeRepo) PublishDue(ctx context.Context, now time.Time, limit int) ([]entities.Publication, error) {
+	rows, err := r.Pool.Query(ctx,
+		"WITH due AS (SELECT id, published_at IS NULL AS first FROM recipes WHERE status=$1 AND publish_at<=$2 AND deleted_at IS NULL"+
+			" ORDER BY publish_at LIMIT $3 FOR UPDATE SKIP LOCKED)"+
+			" UPDATE recipes SET status=$4,publish_at=NULL,published_at=coalesce(published_at,$2),updated_at=$2"+
+			" FROM due WHERE recipes.id=due.id RETURNING recipes.id, recipes.user_id, due.first",
+		entities.StatusScheduled, now, limit, entities.StatusPublished)
+	if err != nil {
+		return nil, fmt.Errorf("RecipeRepo - PublishDue - r.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	publications := make([]entities.Publication, 0, limit)
+	for rows.Next() {
+		var publication entities.Publication
+		err := rows.Scan(&publication.RecipeID, &publication.CreatorID, &publication.First)
+		if err != nil {
+			return nil, fmt.Errorf("RecipeRepo - PublishDue - rows.Scan: %w", err)
+		}
+		publications = append(publications, publication)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("RecipeRepo - PublishDue - rows.Err: %w", err)
+	}
+	return publications, nil
+}
+
+// Delete moves the recipe to the trash of its author.
 func (r *RecipeRepo) Delete(ctx context.Context, recipe *entities.Recipe) error {
-	_, err := r.Pool.Exec(ctx, "DELETE FROM recipes WHERE id=$1", recipe.ID)
+	_, err := r.Pool.Exec(ctx, "UPDATE recipes SET deleted_at=$1 WHERE id=$2 AND deleted_at IS NULL", time.Now(), recipe.ID)
 	if err != nil {
 		return fmt.Errorf("RecipeRepo - Delete - r.Pool.Exec: %w", err)
 	}
@@ -0,0 +1,69 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Homyakadze14/RecipeSite/internal/entities"
+	"github.com/Homyakadze14/RecipeSite/internal/usecases"
+	"github.com/jackc/pgx/v5"
+)
+
+// saveRevision stores the recipe as its next revision. The recipe row is
+// locked, so concurrent updates can't take the same version.
+func (r *RecipeRepo) saveRevision(ctx context.Context, tx pgx.Tx, recipe *entities.Recipe) error {
+	_, err := tx.Exec(ctx, "SELECT id FROM recipes WHERE id=$1 FOR UPDATE", recipe.ID)
+	if err != nil {
+		return fmt.Errorf("RecipeRepo - saveRevision - tx.Exec: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, "INSERT INTO recipe_revisions(recipe_id, version, snapshot, created_at)"+
+		" SELECT $1, coalesce(max(version), 0) + 1, $2, $3 FROM recipe_revisions WHERE recipe_id=$1",
+		recipe.ID, recipe, time.Now())
+	if err != nil {
+		return fmt.Errorf("RecipeRepo - saveRevision - tx.Exec: %w", err)
+	}
+	return nil
+}
+
+// GetRevisions lists the revisions of the recipe without their snapshots, the newest first.
+func (r *RecipeRepo) GetRevisions(ctx context.Context, recipeID int) ([]entities.RecipeRevision, error) {
+	rows, err := r.Pool.Query(ctx, "SELECT recipe_id, version, created_at FROM recipe_revisions"+
+		" WHERE recipe_id=$1 ORDER BY version DESC", recipeID)
+	if err != nil {
+		return nil, fmt.Errorf("RecipeRepo - GetRevisions - r.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	revisions := make([]entities.RecipeRevision, 0, constArraySize)
+	for rows.Next() {
+		var revision entities.RecipeRevision
+		err = rows.Scan(&revision.RecipeID, &revision.Version, &revision.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("RecipeRepo - GetRevisions - rows.Scan: %w", err)
+		}
+		revisions = append(revisions, revision)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("RecipeRepo - GetRevisions - rows.Err: %w", err)
+	}
+
+	return revisions, nil
+}
+
+func (r *RecipeRepo) GetRevision(ctx context.Context, recipeID, version int) (*entities.RecipeRevision, error) {
+	row := r.Pool.QueryRow(ctx, "SELECT recipe_id, version, snapshot, created_at FROM recipe_revisions"+
+		" WHERE recipe_id=$1 AND version=$2", recipeID, version)
+
+	revision := &entities.RecipeRevision{}
+	err := row.Scan(&revision.RecipeID, &revision.Version, &revision.Snapshot, &revision.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, usecases.ErrRevisionNotFound
+		}
+		return nil, fmt.Errorf("RecipeRepo - GetRevision - row.Scan: %w", err)
+	}
+	return revision, nil
+}
@@ -0,0 +1,138 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Homyakadze14/RecipeSite/internal/entities"
+	"github.com/Homyakadze14/RecipeSite/internal/usecases"
+	"github.com/Homyakadze14/RecipeSite/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+)
+
+type ShoppingListRepo struct {
+	*postgres.Postgres
+}
+
+func NewShoppingListRepository(pg *postgres.Postgres) *ShoppingListRepo {
+	return &ShoppingListRepo{pg}
+}
+
+// Get gets the items of the user, the ones to buy go first.
+func (r *ShoppingListRepo) Get(ctx context.Context, userID int) (*entities.ShoppingList, error) {
+	list, err := getShoppingList(ctx, r.Pool, userID)
+	if err != nil {
+		return nil, fmt.Errorf("ShoppingListRepo - Get - getShoppingList: %w", err)
+	}
+	return list, nil
+}
+
+func getShoppingList(ctx context.Context, q querier, userID int) (*entities.ShoppingList, error) {
+	rows, err := q.Query(ctx, "SELECT id, name, quantity, unit, checked FROM shopping_list_items"+
+		" WHERE user_id=$1 ORDER BY checked, id", userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := &entities.ShoppingList{Items: make([]entities.ShoppingListItem, 0, constArraySize)}
+	for rows.Next() {
+		var item entities.ShoppingListItem
+		err = rows.Scan(&item.ID, &item.Name, &item.Quantity, &item.Unit, &item.Checked)
+		if err != nil {
+			return nil, err
+		}
+		list.Items = append(list.Items, item)
+	}
+
+	return list, rows.Err()
+}
+
+func (r *ShoppingListRepo) GetItem(ctx context.Context, userID, id int) (*entities.ShoppingListItem, error) {
+	row := r.Pool.QueryRow(ctx, "SELECT id, name, quantity, unit, checked FROM shopping_list_items WHERE id=$1 AND user_id=$2",
+		id, userID)
+
+	item := &entities.ShoppingListItem{}
+	err := row.Scan(&item.ID, &item.Name, &item.Quantity, &item.Unit, &item.Checked)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, usecases.ErrShoppingListItemNotFound
+		}
+		return nil, fmt.Errorf("ShoppingListRepo - GetItem - row.Scan: %w", err)
+	}
+	return item, nil
+}
+
+// Add merges the ingredients into the list of the user. The user row is
+// locked while the list is merged, so concurrent adds don't lose items.
+func (r *ShoppingListRepo) Add(ctx context.Context, userID int, ingredients []entities.Ingredient) error {
+	tx, err := r.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("ShoppingListRepo - Add - r.Pool.Begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, "SELECT id FROM users WHERE id=$1 FOR NO KEY UPDATE", userID)
+	if err != nil {
+		return fmt.Errorf("ShoppingListRepo - Add - tx.Exec: %w", err)
+	}
+
+	list, err := getShoppingList(ctx, tx, userID)
+	if err != nil {
+		return fmt.Errorf("ShoppingListRepo - Add - getShoppingList: %w", err)
+	}
+
+	list.Add(ingredients)
+
+	batch := &pgx.Batch{}
+	for _, item := range list.Items {
+		if item.ID != 0 {
+			batch.Queue("UPDATE shopping_list_items SET name=$1, quantity=$2, unit=$3, checked=$4 WHERE id=$5 AND user_id=$6",
+				item.Name, item.Quantity, item.Unit, item.Checked, item.ID, userID)
+		} else {
+			batch.Queue("INSERT INTO shopping_list_items(user_id, name, quantity, unit, checked) VALUES ($1,$2,$3,$4,$5)",
+				userID, item.Name, item.Quantity, item.Unit, item.Checked)
+		}
+	}
+
+	err = tx.SendBatch(ctx, batch).Close()
+	if err != nil {
+		return fmt.Errorf("ShoppingListRepo - Add - tx.SendBatch: %w", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("ShoppingListRepo - Add - tx.Commit: %w", err)
+	}
+	return nil
+}
+
+func (r *ShoppingListRepo) UpdateItem(ctx context.Context, userID int, item *entities.ShoppingListItem) error {
+	_, err := r.Pool.Exec(ctx, "UPDATE shopping_list_items SET name=$1, quantity=$2, unit=$3, checked=$4 WHERE id=$5 AND user_id=$6",
+		item.Name, item.Quantity, item.Unit, item.Checked, item.ID, userID)
+	if err != nil {
+		return fmt.Errorf("ShoppingListRepo - UpdateItem - r.Pool.Exec: %w", err)
+	}
+	return nil
+}
+
+func (r *ShoppingListRepo) DeleteItem(ctx context.Context, userID, id int) error {
+	res, err := r.Pool.Exec(ctx, "DELETE FROM shopping_list_items WHERE id=$1 AND user_id=$2", id, userID)
+	if err != nil {
+		return fmt.Errorf("ShoppingListRepo - DeleteItem - r.Pool.Exec: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return usecases.ErrShoppingListItemNotFound
+	}
+	return nil
+}
+
+// Clear removes all the items of the user or only the checked ones.
+func (r *ShoppingListRepo) Clear(ctx context.Context, userID int, onlyChecked bool) (removed int, err error) {
+	res, err := r.Pool.Exec(ctx, "DELETE FROM shopping_list_items WHERE user_id=$1 AND (checked OR NOT $2)", userID, onlyChecked)
+	if err != nil {
+		return 0, fmt.Errorf("ShoppingListRepo - Clear - r.Pool.Exec: %w", err)
+	}
+	return int(res.RowsAffected()), nil
+}
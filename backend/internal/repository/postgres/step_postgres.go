@@ -0,0 +1,49 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Homyakadze14/RecipeSite/internal/entities"
+	"github.com/jackc/pgx/v5"
+)
+
+func (r *RecipeRepo) saveSteps(ctx context.Context, tx pgx.Tx, recipeID int, steps []entities.RecipeStep) error {
+	_, err := tx.Exec(ctx, "DELETE FROM recipe_steps WHERE recipe_id=$1", recipeID)
+	if err != nil {
+		return fmt.Errorf("RecipeRepo - saveSteps - tx.Exec: %w", err)
+	}
+
+	batch := &pgx.Batch{}
+	for i, step := range steps {
+		batch.Queue("INSERT INTO recipe_steps(recipe_id, position, text, duration, photo_url) VALUES ($1,$2,$3,$4,$5)",
+			recipeID, i+1, step.Text, step.Duration, step.PhotoURL)
+	}
+
+	err = tx.SendBatch(ctx, batch).Close()
+	if err != nil {
+		return fmt.Errorf("RecipeRepo - saveSteps - tx.SendBatch: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RecipeRepo) getSteps(ctx context.Context, recipeID int) ([]entities.RecipeStep, error) {
+	rows, err := r.Pool.Query(ctx, "SELECT text, duration, photo_url FROM recipe_steps WHERE recipe_id=$1 ORDER BY position", recipeID)
+	if err != nil {
+		return nil, fmt.Errorf("RecipeRepo - getSteps - r.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	steps := make([]entities.RecipeStep, 0, constArraySize)
+	for rows.Next() {
+		var step entities.RecipeStep
+		err = rows.Scan(&step.Text, &step.Duration, &step.PhotoURL)
+		if err != nil {
+			return nil, fmt.Errorf("RecipeRepo - getSteps - rows.Scan: %w", err)
+		}
+		steps = append(steps, step)
+	}
+
+	return steps, nil
+}
@@ -0,0 +1,130 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/Homyakadze14/RecipeSite/internal/entities"
+	"github.com/Homyakadze14/RecipeSite/internal/usecases"
+	"github.com/Homyakadze14/RecipeSite/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+)
+
+type TagRepo struct {
+	*postgres.Postgres
+}
+
+func NewTagRepository(pg *postgres.Postgres) *TagRepo {
+	return &TagRepo{pg}
+}
+
+func (r *TagRepo) GetAll(ctx context.Context) ([]entities.TagWithCount, error) {
+	rows, err := r.Pool.Query(ctx, "SELECT tags.slug, tags.name, tags.kind, count(recipe_tags.recipe_id) FROM tags"+
+		" LEFT JOIN (recipe_tags JOIN recipes ON recipes.id = recipe_tags.recipe_id AND recipes.status = $1 AND recipes.deleted_at IS NULL)"+
+		" ON recipe_tags.tag_id = tags.id GROUP BY tags.id ORDER BY tags.kind, tags.name", entities.StatusPublished)
+	if err != nil {
+		return nil, fmt.Errorf("TagRepo - GetAll - r.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	tags := make([]entities.TagWithCount, 0, constArraySize)
+	for rows.Next() {
+		var tag entities.TagWithCount
+		err := rows.Scan(&tag.Slug, &tag.Name, &tag.Kind, &tag.RecipesCount)
+		if err != nil {
+			return nil, fmt.Errorf("TagRepo - GetAll - rows.Scan: %w", err)
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
+
+func (r *TagRepo) GetBySlug(ctx context.Context, slug string) (*entities.Tag, error) {
+	row := r.Pool.QueryRow(ctx, "SELECT slug, name, kind FROM tags WHERE slug=$1", slug)
+
+	tag := &entities.Tag{}
+	err := row.Scan(&tag.Slug, &tag.Name, &tag.Kind)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, usecases.ErrTagNotFound
+		}
+		return nil, fmt.Errorf("TagRepo - GetBySlug - row.Scan: %w", err)
+	}
+	return tag, nil
+}
+
+// tagSlugs gets the slugs of the tags without repeats.
+func tagSlugs(tags []entities.Tag) []string {
+	slugs := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		slugs = append(slugs, tag.Slug)
+	}
+	slices.Sort(slugs)
+	return slices.Compact(slugs)
+}
+
+// CheckTags returns ErrTagNotFound if one of the tags doesn't exist.
+func (r *RecipeRepo) CheckTags(ctx context.Context, tags []entities.Tag) error {
+	slugs := tagSlugs(tags)
+	if len(slugs) == 0 {
+		return nil
+	}
+
+	row := r.Pool.QueryRow(ctx, "SELECT count(*) FROM tags WHERE slug = ANY($1)", slugs)
+
+	var count int
+	err := row.Scan(&count)
+	if err != nil {
+		return fmt.Errorf("RecipeRepo - CheckTags - row.Scan: %w", err)
+	}
+	if count != len(slugs) {
+		return usecases.ErrTagNotFound
+	}
+	return nil
+}
+
+func (r *RecipeRepo) saveTags(ctx context.Context, tx pgx.Tx, recipeID int, tags []entities.Tag) error {
+	_, err := tx.Exec(ctx, "DELETE FROM recipe_tags WHERE recipe_id=$1", recipeID)
+	if err != nil {
+		return fmt.Errorf("RecipeRepo - saveTags - tx.Exec: %w", err)
+	}
+
+	slugs := tagSlugs(tags)
+	if len(slugs) == 0 {
+		return nil
+	}
+
+	res, err := tx.Exec(ctx, "INSERT INTO recipe_tags(recipe_id, tag_id) SELECT $1, id FROM tags WHERE slug = ANY($2)", recipeID, slugs)
+	if err != nil {
+		return fmt.Errorf("RecipeRepo - saveTags - tx.Exec: %w", err)
+	}
+	if res.RowsAffected() != int64(len(slugs)) {
+		return usecases.ErrTagNotFound
+	}
+
+	return nil
+}
+
+func (r *RecipeRepo) getTags(ctx context.Context, recipeID int) ([]entities.Tag, error) {
+	rows, err := r.Pool.Query(ctx, "SELECT tags.slug, tags.name, tags.kind FROM tags"+
+		" JOIN recipe_tags ON recipe_tags.tag_id = tags.id WHERE recipe_tags.recipe_id=$1 ORDER BY tags.kind, tags.name", recipeID)
+	if err != nil {
+		return nil, fmt.Errorf("RecipeRepo - getTags - r.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	tags := make([]entities.Tag, 0, constArraySize)
+	for rows.Next() {
+		var tag entities.Tag
+		err = rows.Scan(&tag.Slug, &tag.Name, &tag.Kind)
+		if err != nil {
+			return nil, fmt.Errorf("RecipeRepo - getTags - rows.Scan: %w", err)
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
@@ -0,0 +1,191 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Homyakadze14/RecipeSite/internal/entities"
+	"github.com/Homyakadze14/RecipeSite/internal/usecases"
+	"github.com/Homyakadze14/RecipeSite/pkg/postgres"
+)
+
+type TrashRepo struct {
+	*postgres.Postgres
+}
+
+func NewTrashRepository(pg *postgres.Postgres) *TrashRepo {
+	return &TrashRepo{pg}
+}
+
+func (r *TrashRepo) GetRecipes(ctx context.Context, userID int) ([]entities.Recipe, error) {
+	rows, err := r.Pool.Query(ctx, "SELECT "+recipeFields+", recipes.deleted_at FROM recipes"+
+		" WHERE user_id=$1 AND deleted_at IS NOT NULL ORDER BY deleted_at DESC", userID)
+	if err != nil {
+		return nil, fmt.Errorf("TrashRepo - GetRecipes - r.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	recipes := make([]entities.Recipe, 0, constArraySize)
+	for rows.Next() {
+		var recipe entities.Recipe
+		err := scanRecipe(rows, &recipe, &recipe.DeletedAt)
+		if err != nil {
+			return nil, fmt.Errorf("TrashRepo - GetRecipes - rows.Scan: %w", err)
+		}
+		recipes = append(recipes, recipe)
+	}
+
+	return recipes, nil
+}
+
+func (r *TrashRepo) GetComments(ctx context.Context, userID int) ([]entities.TrashComment, error) {
+	rows, err := r.Pool.Query(ctx, "SELECT id, recipe_id, text, created_at, deleted_at FROM comments"+
+		" WHERE user_id=$1 AND deleted_at IS NOT NULL ORDER BY deleted_at DESC", userID)
+	if err != nil {
+		return nil, fmt.Errorf("TrashRepo - GetComments - r.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	comments := make([]entities.TrashComment, 0, constArraySize)
+	for rows.Next() {
+		var comment entities.TrashComment
+		err := rows.Scan(&comment.ID, &comment.RecipeID, &comment.Text, &comment.CreatedAt, &comment.DeletedAt)
+		if err != nil {
+			return nil, fmt.Errorf("TrashRepo - GetComments - rows.Scan: %w", err)
+		}
+		comments = append(comments, comment)
+	}
+
+	return comments, nil
+}
+
+// RestoreRecipe takes the recipe of the user out of the trash if it was deleted after the time.
+func (r *TrashRepo) RestoreRecipe(ctx context.Context, id, userID int, deletedAfter time.Time) error {
+	res, err := r.Pool.Exec(ctx, "UPDATE recipes SET deleted_at=NULL WHERE id=$1 AND user_id=$2 AND deleted_at>$3",
+		id, userID, deletedAfter)
+	if err != nil {
+		return fmt.Errorf("TrashRepo - RestoreRecipe - r.Pool.Exec: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return usecases.ErrRecipeNotFound
+	}
+	return nil
+}
+
+// RestoreComment takes the comment of the user out of the trash if it was deleted after the time.
+func (r *TrashRepo) RestoreComment(ctx context.Context, id, userID int, deletedAfter time.Time) error {
+	res, err := r.Pool.Exec(ctx, "UPDATE comments SET deleted_at=NULL WHERE id=$1 AND user_id=$2 AND deleted_at>$3",
+		id, userID, deletedAfter)
+	if err != nil {
+		return fmt.Errorf("TrashRepo - RestoreComment - r.Pool.Exec: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return usecases.ErrCommentNotFound
+	}
+	return nil
+}
+
+// Purge removes up to limit recipes deleted before the time together with
+// expired comments and users. It returns the urls of the photos left behind.
+// Users are removed only after all their recipes, so no photo is lost.
+func (r *TrashRepo) Purge(ctx context.Context, deletedBefore time.Time, limit int) (files []string, recipes int, err error) {
+	tx, err := r.Pool.Begin(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("TrashRepo - Purge - r.Pool.Begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, "SELECT id, photos_urls FROM recipes WHERE deleted_at<$1"+
+		" ORDER BY deleted_at LIMIT $2 FOR UPDATE SKIP LOCKED", deletedBefore, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("TrashRepo - Purge - tx.Query: %w", err)
+	}
+
+	ids := make([]int, 0, limit)
+	files = make([]string, 0, limit)
+	for rows.Next() {
+		var id int
+		var photos string
+		err = rows.Scan(&id, &photos)
+		if err != nil {
+			rows.Close()
+			return nil, 0, fmt.Errorf("TrashRepo - Purge - rows.Scan: %w", err)
+		}
+		ids = append(ids, id)
+		photos = strings.TrimSuffix(photos, ";")
+		if photos != "" {
+			files = append(files, strings.Split(photos, ";")...)
+		}
+	}
+	rows.Close()
+
+	rows, err = tx.Query(ctx, "SELECT photo_url FROM recipe_steps WHERE recipe_id = ANY($1) AND photo_url <> ''"+
+		" UNION ALL SELECT photo_url FROM cook_logs WHERE recipe_id = ANY($1) AND photo_url <> ''", ids)
+	if err != nil {
+		return nil, 0, fmt.Errorf("TrashRepo - Purge - tx.Query: %w", err)
+	}
+	for rows.Next() {
+		var photo string
+		err = rows.Scan(&photo)
+		if err != nil {
+			rows.Close()
+			return nil, 0, fmt.Errorf("TrashRepo - Purge - rows.Scan: %w", err)
+		}
+		files = append(files, photo)
+	}
+	rows.Close()
+
+	_, err = tx.Exec(ctx, "DELETE FROM recipes WHERE id = ANY($1)", ids)
+	if err != nil {
+		return nil, 0, fmt.Errorf("TrashRepo - Purge - tx.Exec: %w", err)
+	}
+
+	// Forks share photos with the recipes they were copied from.
+	files, err = unusedPhotos(ctx, tx, files)
+	if err != nil {
+		return nil, 0, fmt.Errorf("TrashRepo - Purge - unusedPhotos: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, "DELETE FROM comments WHERE deleted_at<$1", deletedBefore)
+	if err != nil {
+		return nil, 0, fmt.Errorf("TrashRepo - Purge - tx.Exec: %w", err)
+	}
+
+	// Users deleted before their sessions were removed with them still have some.
+	_, err = tx.Exec(ctx, "DELETE FROM sessions WHERE user_id IN (SELECT id FROM users WHERE deleted_at<$1)", deletedBefore)
+	if err != nil {
+		return nil, 0, fmt.Errorf("TrashRepo - Purge - tx.Exec: %w", err)
+	}
+
+	// Collections and cook logs of the users are deleted with them, so their
+	// covers and photos are returned too.
+	rows, err = tx.Query(ctx, "WITH purged AS (DELETE FROM users WHERE deleted_at<$1"+
+		" AND NOT EXISTS (SELECT 1 FROM recipes WHERE recipes.user_id = users.id) RETURNING id, icon_url)"+
+		" SELECT icon_url FROM purged UNION ALL"+
+		" SELECT cover_url FROM collections WHERE user_id IN (SELECT id FROM purged) AND cover_url <> '' UNION ALL"+
+		" SELECT photo_url FROM cook_logs WHERE user_id IN (SELECT id FROM purged) AND photo_url <> ''", deletedBefore)
+	if err != nil {
+		return nil, 0, fmt.Errorf("TrashRepo - Purge - tx.Query: %w", err)
+	}
+	for rows.Next() {
+		var icon string
+		err = rows.Scan(&icon)
+		if err != nil {
+			rows.Close()
+			return nil, 0, fmt.Errorf("TrashRepo - Purge - rows.Scan: %w", err)
+		}
+		files = append(files, icon)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("TrashRepo - Purge - rows.Err: %w", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("TrashRepo - Purge - tx.Commit: %w", err)
+	}
+	return files, len(ids), nil
+}
@@ -17,6 +17,8 @@ type UserRepo struct {
 	*postgres.Postgres
 }
 
+const userFields = "id, email, login, password, about, icon_url, created_at"
+
 func NewUserRepository(pg *postgres.Postgres) *UserRepo {
 	return &UserRepo{pg}
 }
@@ -35,7 +37,7 @@ func (r *UserRepo) Create(ctx context.Context, user *entities.User) (id int, err
 }
 
 func (r *UserRepo) GetByLogin(ctx context.Context, login string) (*entities.User, error) {
-	row := r.Pool.QueryRow(ctx, "SELECT * FROM users WHERE login=$1", login)
+	row := r.Pool.QueryRow(ctx, "SELECT "+userFields+" FROM users WHERE login=$1 AND deleted_at IS NULL", login)
 	usr := &entities.User{}
 	err := row.Scan(&usr.ID, &usr.Email, &usr.Login, &usr.Password, &usr.About, &usr.IconURL, &usr.CreatedAt)
 	if err != nil {
@@ -48,7 +50,7 @@ func (r *UserRepo) GetByLogin(ctx context.Context, login string) (*entities.User
 }
 
 func (r *UserRepo) GetIconByLogin(ctx context.Context, login string) (*entities.UserIcon, error) {
-	row := r.Pool.QueryRow(ctx, "SELECT icon_url FROM users WHERE login=$1", login)
+	row := r.Pool.QueryRow(ctx, "SELECT icon_url FROM users WHERE login=$1 AND deleted_at IS NULL", login)
 	icn := &entities.UserIcon{}
 	err := row.Scan(&icn.IconURL)
 	if err != nil {
@@ -61,7 +63,7 @@ func (r *UserRepo) GetIconByLogin(ctx context.Context, login string) (*entities.
 }
 
 func (r *UserRepo) GetByEmail(ctx context.Context, email string) (*entities.User, error) {
-	row := r.Pool.QueryRow(ctx, "SELECT * FROM users WHERE email=$1", email)
+	row := r.Pool.QueryRow(ctx, "SELECT "+userFields+" FROM users WHERE email=$1 AND deleted_at IS NULL", email)
 	usr := &entities.User{}
 	err := row.Scan(&usr.ID, &usr.Email, &usr.Login, &usr.Password, &usr.About, &usr.IconURL, &usr.CreatedAt)
 	if err != nil {
@@ -86,6 +88,26 @@ func (r *UserRepo) GetAuthor(ctx context.Context, id int) (*entities.Author, err
 	return usr, nil
 }
 
+func (r *UserRepo) GetAuthors(ctx context.Context, ids []int) (map[int]*entities.Author, error) {
+	rows, err := r.Pool.Query(ctx, "SELECT id, login, icon_url FROM users WHERE id = ANY($1)", ids)
+	if err != nil {
+		return nil, fmt.Errorf("UserRepo - GetAuthors - r.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	authors := make(map[int]*entities.Author, len(ids))
+	for rows.Next() {
+		var id int
+		author := &entities.Author{}
+		err := rows.Scan(&id, &author.Login, &author.IconURL)
+		if err != nil {
+			return nil, fmt.Errorf("UserRepo - GetAuthors - rows.Scan: %w", err)
+		}
+		authors[id] = author
+	}
+	return authors, nil
+}
+
 func (r *UserRepo) Update(ctx context.Context, user *entities.User) error {
 	_, err := r.Pool.Exec(ctx, "UPDATE users SET email=$1, login=$2, icon_url=$3, about=$4 WHERE id=$5",
 		user.Email, user.Login, user.IconURL, user.About, user.ID)
@@ -108,8 +130,11 @@ func (r *UserRepo) UpdatePassword(ctx context.Context, user *entities.User) erro
 	return nil
 }
 
-func (r *UserRepo) GetRecipes(ctx context.Context, userID int) ([]entities.Recipe, error) {
-	rows, err := r.Pool.Query(ctx, "SELECT * FROM recipes WHERE user_id=$1", userID)
+// GetRecipes returns the recipes written by the user. Recipes which aren't
+// published are returned only with hidden set.
+func (r *UserRepo) GetRecipes(ctx context.Context, userID int, hidden bool) ([]entities.Recipe, error) {
+	rows, err := r.Pool.Query(ctx, "SELECT "+recipeFields+" FROM recipes WHERE user_id=$1 AND (status=$2 OR $3) AND deleted_at IS NULL",
+		userID, entities.StatusPublished, hidden)
 	if err != nil {
 		return nil, fmt.Errorf("UserRepo - GetRecipes - r.Pool.Query: %w", err)
 	}
@@ -117,9 +142,7 @@ func (r *UserRepo) GetRecipes(ctx context.Context, userID int) ([]entities.Recip
 	recipes := make([]entities.Recipe, 0, constArraySize)
 	for rows.Next() {
 		var recipe entities.Recipe
-		err := rows.Scan(&recipe.ID, &recipe.UserID, &recipe.Title, &recipe.About,
-			&recipe.Complexitiy, &recipe.NeedTime, &recipe.Ingridients, &recipe.Instructions,
-			&recipe.PhotosUrls, &recipe.CreatedAt, &recipe.UpdatedAt)
+		err := scanRecipe(rows, &recipe)
 		if err != nil {
 			return nil, fmt.Errorf("UserRepo - GetRecipes - rows.Scan: %w", err)
 		}
@@ -143,3 +166,92 @@ func (r *UserRepo) IsAlreadySubscribe(ctx context.Context, info *entities.Subscr
 
 	return true, nil
 }
+
+// Delete moves the user to the trash together with the recipes and comments
+// the user has. They all get the same deleted_at, so they are restored together.
+func (r *UserRepo) Delete(ctx context.Context, userID int) (recipeIDs []int, err error) {
+	tx, err := r.Pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("UserRepo - Delete - r.Pool.Begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	now := time.Now()
+	_, err = tx.Exec(ctx, "UPDATE users SET deleted_at=$1 WHERE id=$2", now, userID)
+	if err != nil {
+		return nil, fmt.Errorf("UserRepo - Delete - tx.Exec: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE comments SET deleted_at=$1 WHERE user_id=$2 AND deleted_at IS NULL", now, userID)
+	if err != nil {
+		return nil, fmt.Errorf("UserRepo - Delete - tx.Exec: %w", err)
+	}
+
+	// Sessions are removed with the soft delete, so no session is left to
+	// keep the user from being purged.
+	_, err = tx.Exec(ctx, "DELETE FROM sessions WHERE user_id=$1", userID)
+	if err != nil {
+		return nil, fmt.Errorf("UserRepo - Delete - tx.Exec: %w", err)
+	}
+
+	rows, err := tx.Query(ctx, "UPDATE recipes SET deleted_at=$1 WHERE user_id=$2 AND deleted_at IS NULL RETURNING id", now, userID)
+	if err != nil {
+		return nil, fmt.Errorf("UserRepo - Delete - tx.Query: %w", err)
+	}
+	recipeIDs, err = pgx.CollectRows(rows, pgx.RowTo[int])
+	if err != nil {
+		return nil, fmt.Errorf("UserRepo - Delete - pgx.CollectRows: %w", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("UserRepo - Delete - tx.Commit: %w", err)
+	}
+	return recipeIDs, nil
+}
+
+// GetDeleted finds a deleted user by the login or the email.
+func (r *UserRepo) GetDeleted(ctx context.Context, login, email string) (*entities.User, error) {
+	row := r.Pool.QueryRow(ctx, "SELECT "+userFields+", deleted_at FROM users"+
+		" WHERE (login=$1 OR email=$2) AND deleted_at IS NOT NULL", login, email)
+	usr := &entities.User{}
+	err := row.Scan(&usr.ID, &usr.Email, &usr.Login, &usr.Password, &usr.About, &usr.IconURL, &usr.CreatedAt, &usr.DeletedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, usecases.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("UserRepo - GetDeleted - r.Pool.QueryRow: %w", err)
+	}
+	return usr, nil
+}
+
+// Restore takes the user out of the trash with the recipes and comments
+// deleted together with the user.
+func (r *UserRepo) Restore(ctx context.Context, user *entities.User) error {
+	tx, err := r.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("UserRepo - Restore - r.Pool.Begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, "UPDATE users SET deleted_at=NULL WHERE id=$1", user.ID)
+	if err != nil {
+		return fmt.Errorf("UserRepo - Restore - tx.Exec: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE recipes SET deleted_at=NULL WHERE user_id=$1 AND deleted_at=$2", user.ID, user.DeletedAt)
+	if err != nil {
+		return fmt.Errorf("UserRepo - Restore - tx.Exec: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE comments SET deleted_at=NULL WHERE user_id=$1 AND deleted_at=$2", user.ID, user.DeletedAt)
+	if err != nil {
+		return fmt.Errorf("UserRepo - Restore - tx.Exec: %w", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("UserRepo - Restore - tx.Commit: %w", err)
+	}
+	return nil
+}
@@ -10,10 +10,16 @@ import (
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
+const (
+	newRecipeQueue  = "new_recipe"
+	recipeForkQueue = "recipe_fork"
+)
+
 type SubscribeRabbitMQRepo struct {
-	rmq     *amqp.Connection
-	channel *amqp.Channel
-	queue   amqp.Queue
+	rmq       *amqp.Connection
+	channel   *amqp.Channel
+	queue     amqp.Queue
+	forkQueue amqp.Queue
 }
 
 func NewSubscribeRabbitMQRepository(rabbitmq *amqp.Connection) (*SubscribeRabbitMQRepo, error) {
@@ -23,10 +29,16 @@ func NewSubscribeRabbitMQRepository(rabbitmq *amqp.Connection) (*SubscribeRabbit
 		return nil, err
 	}
 
+	forkQue, err := declareQueue(ch, recipeForkQueue)
+	if err != nil {
+		return nil, err
+	}
+
 	return &SubscribeRabbitMQRepo{
 		rabbitmq,
 		ch,
 		que,
+		forkQue,
 	}, nil
 }
 
@@ -36,8 +48,17 @@ func newChannelAndQueue(rmq *amqp.Connection) (*amqp.Channel, amqp.Queue, error)
 		return nil, amqp.Queue{}, fmt.Errorf("SubscribeRabbitMQRepository - newChannelAndQueue - u.rmq.Channel: %w", err)
 	}
 
+	q, err := declareQueue(ch, newRecipeQueue)
+	if err != nil {
+		return nil, amqp.Queue{}, err
+	}
+
+	return ch, q, nil
+}
+
+func declareQueue(ch *amqp.Channel, name string) (amqp.Queue, error) {
 	q, err := ch.QueueDeclare(
-		"new_recipe",
+		name,
 		false,
 		false,
 		false,
@@ -45,10 +66,10 @@ func newChannelAndQueue(rmq *amqp.Connection) (*amqp.Channel, amqp.Queue, error)
 		nil,
 	)
 	if err != nil {
-		return nil, amqp.Queue{}, fmt.Errorf("SubscribeRabbitMQRepository - newChannelAndQueue - ch.QueueDeclare: %w", err)
+		return amqp.Queue{}, fmt.Errorf("SubscribeRabbitMQRepository - declareQueue - ch.QueueDeclare: %w", err)
 	}
 
-	return ch, q, nil
+	return q, nil
 }
 
 func (u *SubscribeRabbitMQRepo) CloseChan() error {
@@ -80,3 +101,25 @@ func (u *SubscribeRabbitMQRepo) Send(ctx context.Context, message *entities.Reci
 	slog.Info(fmt.Sprintf("Recipe with creator %v and post_id %v has been sent to rmq", message.CreatorID, message.RecipeID))
 	return nil
 }
+
+func (u *SubscribeRabbitMQRepo) SendFork(ctx context.Context, message *entities.RecipeForkMsg) error {
+	body, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("SubscribeRabbitMQRepository - SendFork - json.Marshal: %w", err)
+	}
+	err = u.channel.PublishWithContext(ctx,
+		"",
+		u.forkQueue.Name,
+		false,
+		false,
+		amqp.Publishing{
+			ContentType: "application/json",
+			Body:        body,
+		})
+	if err != nil {
+		return fmt.Errorf("SubscribeRabbitMQRepository - SendFork - ch.PublishWithContext: %w", err)
+	}
+
+	slog.Info(fmt.Sprintf("Fork %v of recipe %v has been sent to rmq", message.ForkID, message.RecipeID))
+	return nil
+}
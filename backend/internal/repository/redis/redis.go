@@ -55,3 +55,51 @@ func (r *RedisRepo) Del(ctx context.Context, key string) (res int64, err error)
 	}
 	return res, nil
 }
+
+// MGet gets the values of the keys at once. The value of keys[i] is
+// unmarshalled into dest[i] when found[i] is true.
+func (r *RedisRepo) MGet(ctx context.Context, keys []string, dest []interface{}) (found []bool, err error) {
+	found = make([]bool, len(keys))
+	if len(keys) == 0 {
+		return found, nil
+	}
+
+	values, err := r.redis.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("RedisRepo - MGet - r.redis.MGet: %w", err)
+	}
+
+	for i, value := range values {
+		s, ok := value.(string)
+		if !ok {
+			continue
+		}
+		if err := json.Unmarshal([]byte(s), dest[i]); err != nil {
+			return nil, fmt.Errorf("RedisRepo - MGet - json.Unmarshal: %w", err)
+		}
+		found[i] = true
+	}
+	return found, nil
+}
+
+// MSet sets the values in one round trip.
+func (r *RedisRepo) MSet(ctx context.Context, values map[string]interface{}) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	pipe := r.redis.Pipeline()
+	for key, value := range values {
+		p, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("RedisRepo - MSet - json.Marshal: %w", err)
+		}
+		pipe.Set(ctx, key, p, _defaultExperationTime)
+	}
+
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("RedisRepo - MSet - pipe.Exec: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package units
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var ErrBadDuration = errors.New("duration must look like 1h 30m, 90, 1:30 or PT1H30M")
+
+var (
+	isoDurationRe   = regexp.MustCompile(`^P(?:(\d+)D)?(?:T(?:(\d+(?:\.\d+)?)H)?(?:(\d+(?:\.\d+)?)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+	clockDurationRe = regexp.MustCompile(`^(\d+):(\d{1,2})$`)
+	durationPartRe  = regexp.MustCompile(`(\d+(?:[.,]\d+)?)\s*([a-zа-яё]*)\.?`)
+)
+
+// durationUnits are the unit names and their sizes in minutes.
+var durationUnits = map[string]float64{
+	"d": 24 * 60, "day": 24 * 60, "days": 24 * 60,
+	"д": 24 * 60, "дн": 24 * 60, "день": 24 * 60, "дня": 24 * 60, "дней": 24 * 60,
+	"h": 60, "hr": 60, "hrs": 60, "hour": 60, "hours": 60,
+	"ч": 60, "час": 60, "часа": 60, "часов": 60,
+	"m": 1, "min": 1, "mins": 1, "minute": 1, "minutes": 1,
+	"м": 1, "мин": 1, "минута": 1, "минуты": 1, "минут": 1, "минуту": 1,
+}
+
+// ParseDuration parses a cooking time to minutes. It accepts ISO-8601
+// durations (PT1H30M), clock times (1:30), plain minutes (90) and free text
+// like "1h 30m", "1.5 hours" or "1 час 30 минут".
+func ParseDuration(s string) (int, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return 0, ErrBadDuration
+	}
+
+	if m := isoDurationRe.FindStringSubmatch(strings.ToUpper(s)); m != nil && s != "p" && s != "pt" {
+		days, _ := strconv.ParseFloat(zeroIfEmpty(m[1]), 64)
+		hours, _ := strconv.ParseFloat(zeroIfEmpty(m[2]), 64)
+		minutes, _ := strconv.ParseFloat(zeroIfEmpty(m[3]), 64)
+		seconds, _ := strconv.ParseFloat(zeroIfEmpty(m[4]), 64)
+		return int(math.Round(days*24*60 + hours*60 + minutes + seconds/60)), nil
+	}
+
+	if m := clockDurationRe.FindStringSubmatch(s); m != nil {
+		hours, _ := strconv.Atoi(m[1])
+		minutes, _ := strconv.Atoi(m[2])
+		return hours*60 + minutes, nil
+	}
+
+	// Anything but spaces between the parts makes the whole text a bad
+	// duration, so "1h blah 30m" isn't read as 90 minutes.
+	parts := durationPartRe.FindAllStringSubmatch(s, -1)
+	if len(parts) == 0 || strings.TrimSpace(durationPartRe.ReplaceAllString(s, "")) != "" {
+		return 0, ErrBadDuration
+	}
+
+	total := 0.0
+	for _, part := range parts {
+		value, err := strconv.ParseFloat(strings.Replace(part[1], ",", ".", 1), 64)
+		if err != nil {
+			return 0, ErrBadDuration
+		}
+
+		size, ok := durationUnitSize(part[2])
+		if !ok {
+			return 0, ErrBadDuration
+		}
+		total += value * size
+	}
+	return int(math.Round(total)), nil
+}
+
+func durationUnitSize(unit string) (float64, bool) {
+	// A bare number means minutes.
+	if unit == "" {
+		return 1, true
+	}
+	size, ok := durationUnits[unit]
+	return size, ok
+}
+
+func zeroIfEmpty(s string) string {
+	if s == "" {
+		return "0"
+	}
+	return s
+}
+
+// FormatDuration prints minutes like "1h 30m".
+func FormatDuration(minutes int) string {
+	switch {
+	case minutes <= 0:
+		return ""
+	case minutes < 60:
+		return fmt.Sprintf("%vm", minutes)
+	case minutes%60 == 0:
+		return fmt.Sprintf("%vh", minutes/60)
+	default:
+		return fmt.Sprintf("%vh %vm", minutes/60, minutes%60)
+	}
+}
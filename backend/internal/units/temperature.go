@@ -0,0 +1,46 @@
+package units
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// temperatureRe matches temperatures like "180°C", "350 °F" or "180 °С" (cyrillic).
+var temperatureRe = regexp.MustCompile(`(\d+(?:[.,]\d+)?)\s*°\s*([CFС])`)
+
+func CelsiusToFahrenheit(c float64) float64 {
+	return c*9/5 + 32
+}
+
+func FahrenheitToCelsius(f float64) float64 {
+	return (f - 32) * 5 / 9
+}
+
+// ConvertTemperatures rewrites temperatures found in the text to the given
+// system. Oven temperatures are rounded to 5 degrees.
+func ConvertTemperatures(text string, system System) string {
+	return temperatureRe.ReplaceAllStringFunc(text, func(match string) string {
+		m := temperatureRe.FindStringSubmatch(match)
+		value, err := strconv.ParseFloat(strings.Replace(m[1], ",", ".", 1), 64)
+		if err != nil {
+			return match
+		}
+
+		celsius := m[2] != "F"
+		switch {
+		case celsius && system == Imperial:
+			return fmt.Sprintf("%v°F", roundTo5(CelsiusToFahrenheit(value)))
+		case !celsius && system == Metric:
+			return fmt.Sprintf("%v°C", roundTo5(FahrenheitToCelsius(value)))
+		default:
+			return match
+		}
+	})
+}
+
+func roundTo5(v float64) float64 {
+	return math.Round(v/5) * 5
+}
@@ -0,0 +1,300 @@
+// Package units converts kitchen quantities between units and measurement systems.
+package units
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type Kind int
+
+const (
+	Unknown Kind = iota
+	Mass
+	Volume
+)
+
+type System string
+
+const (
+	Metric   System = "metric"
+	Imperial System = "imperial"
+)
+
+type unit struct {
+	Name   string
+	Kind   Kind
+	System System
+	// Size is the amount of grams (mass) or milliliters (volume) in one unit.
+	Size float64
+	// Min is the smallest amount (in this unit) which still reads well.
+	Min float64
+}
+
+var knownUnits = []unit{
+	{"mg", Mass, Metric, 0.001, 0},
+	{"g", Mass, Metric, 1, 1},
+	{"kg", Mass, Metric, 1000, 1},
+	{"мг", Mass, Metric, 0.001, 0},
+	{"г", Mass, Metric, 1, 1},
+	{"кг", Mass, Metric, 1000, 1},
+	{"oz", Mass, Imperial, 28.349523125, 0},
+	{"lb", Mass, Imperial, 453.59237, 1},
+	{"ml", Volume, Metric, 1, 0},
+	{"l", Volume, Metric, 1000, 1},
+	{"мл", Volume, Metric, 1, 0},
+	{"л", Volume, Metric, 1000, 1},
+	// Spoons are used in both systems.
+	{"tsp", Volume, "", 4.92892159375, 0},
+	{"tbsp", Volume, "", 14.78676478125, 1},
+	{"cup", Volume, Imperial, 236.5882365, 0.25},
+}
+
+var aliases = map[string]string{
+	"cups": "cup",
+	"lbs":  "lb",
+	"gr":   "g",
+	"гр":   "г",
+}
+
+// ladders group units which are interchangeable when normalizing a quantity,
+// from the smallest unit to the biggest one.
+var ladders = [][]string{
+	{"mg", "g", "kg"},
+	{"мг", "г", "кг"},
+	{"oz", "lb"},
+	{"ml", "l"},
+	{"мл", "л"},
+	{"tsp", "tbsp", "cup"},
+}
+
+// densities are grams in one milliliter of common ingredients. They let
+// cups be converted to grams and back.
+var densities = map[string]float64{
+	"flour":   0.53,
+	"мука":    0.53,
+	"sugar":   0.85,
+	"сахар":   0.85,
+	"butter":  0.911,
+	"масло":   0.911,
+	"oil":     0.92,
+	"water":   1,
+	"вода":    1,
+	"milk":    1.03,
+	"молоко":  1.03,
+	"cream":   1.01,
+	"сливки":  1.01,
+	"rice":    0.78,
+	"рис":     0.78,
+	"oats":    0.41,
+	"овсяные": 0.41,
+	"salt":    1.2,
+	"соль":    1.2,
+	"honey":   1.42,
+	"мед":     1.42,
+	"мёд":     1.42,
+	"cocoa":   0.42,
+	"какао":   0.42,
+	"starch":  0.6,
+	"крахмал": 0.6,
+}
+
+// metricTargets and imperialTargets are the units a quantity is converted to
+// before normalization.
+var (
+	metricTargets   = map[Kind]string{Mass: "g", Volume: "ml"}
+	imperialTargets = map[Kind]string{Mass: "oz", Volume: "cup"}
+)
+
+// fractionUnits are shown as kitchen fractions rather than decimals.
+var fractionUnits = []string{"", "tsp", "tbsp", "cup", "pcs", "шт"}
+
+var fractions = []struct {
+	value float64
+	text  string
+}{
+	{0, ""}, {1.0 / 8, "1/8"}, {1.0 / 4, "1/4"}, {1.0 / 3, "1/3"}, {3.0 / 8, "3/8"}, {1.0 / 2, "1/2"},
+	{5.0 / 8, "5/8"}, {2.0 / 3, "2/3"}, {3.0 / 4, "3/4"}, {7.0 / 8, "7/8"}, {1, ""},
+}
+
+func lookup(name string) (unit, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if alias, ok := aliases[name]; ok {
+		name = alias
+	}
+	for _, u := range knownUnits {
+		if u.Name == name {
+			return u, true
+		}
+	}
+	return unit{}, false
+}
+
+// Density finds the density of the ingredient by its name.
+func Density(ingredient string) (float64, bool) {
+	for _, word := range strings.Fields(strings.ToLower(ingredient)) {
+		if density, ok := densities[strings.Trim(word, ",.;()")]; ok {
+			return density, true
+		}
+	}
+	return 0, false
+}
+
+// Convert moves the quantity of the ingredient to the given system. Units
+// which are unknown or already belong to the system are only normalized.
+// With a known density metric volumes become grams for the metric system and
+// metric masses become cups for the imperial one.
+func Convert(quantity float64, unitName, ingredient string, system System) (float64, string) {
+	from, ok := lookup(unitName)
+	if !ok {
+		return quantity, unitName
+	}
+	if from.System == "" || from.System == system {
+		// Normalization may climb from spoons to cups, which still have to
+		// be converted for metric readers.
+		normalized, name := Normalize(quantity, unitName)
+		to, _ := lookup(name)
+		if to.System == "" || to.System == system {
+			return normalized, name
+		}
+		quantity, from = normalized, to
+	}
+
+	kind := from.Kind
+	base := quantity * from.Size
+	if density, ok := Density(ingredient); ok {
+		switch {
+		case system == Metric && kind == Volume:
+			base, kind = base*density, Mass
+		case system == Imperial && kind == Mass:
+			base, kind = base/density, Volume
+		}
+	}
+
+	targets := metricTargets
+	if system == Imperial {
+		targets = imperialTargets
+	}
+	to, _ := lookup(targets[kind])
+	return Normalize(base/to.Size, to.Name)
+}
+
+// Normalize moves the quantity to the unit which reads best, e.g. 1500 g -> 1.5 kg.
+func Normalize(quantity float64, unitName string) (float64, string) {
+	from, ok := lookup(unitName)
+	if !ok {
+		return quantity, unitName
+	}
+
+	base := quantity * from.Size
+	for _, ladder := range ladders {
+		for _, name := range ladder {
+			if name != from.Name {
+				continue
+			}
+
+			for i := len(ladder) - 1; i >= 0; i-- {
+				to, _ := lookup(ladder[i])
+				if value := base / to.Size; value >= to.Min {
+					return value, to.Name
+				}
+			}
+		}
+	}
+
+	return quantity, unitName
+}
+
+// Round rounds the quantity the way it is measured in a kitchen: fractions for
+// spoons, cups and pieces, three significant digits for everything else.
+// It returns the rounded quantity and its text representation.
+func Round(quantity float64, unitName string) (float64, string) {
+	name := strings.ToLower(unitName)
+	if alias, ok := aliases[name]; ok {
+		name = alias
+	}
+
+	for _, fractionUnit := range fractionUnits {
+		if name == fractionUnit {
+			return roundToFraction(quantity)
+		}
+	}
+
+	if quantity == 0 {
+		return 0, "0"
+	}
+	magnitude := math.Pow(10, 2-math.Floor(math.Log10(math.Abs(quantity))))
+	rounded := math.Round(quantity*magnitude) / magnitude
+	return rounded, format(rounded)
+}
+
+func roundToFraction(quantity float64) (float64, string) {
+	whole := math.Floor(quantity)
+	rest := quantity - whole
+
+	nearest := 0
+	for i := range fractions {
+		if math.Abs(fractions[i].value-rest) < math.Abs(fractions[nearest].value-rest) {
+			nearest = i
+		}
+	}
+
+	// Never round a present ingredient down to nothing.
+	if whole == 0 && nearest == 0 {
+		nearest = 1
+	}
+
+	value := whole + fractions[nearest].value
+	switch {
+	case fractions[nearest].text == "":
+		return value, format(value)
+	case whole == 0:
+		return value, fractions[nearest].text
+	default:
+		return value, fmt.Sprintf("%v %s", whole, fractions[nearest].text)
+	}
+}
+
+func format(q float64) string {
+	return strconv.FormatFloat(q, 'f', -1, 64)
+}
+
+// ToGrams converts a mass or a volume of the ingredient to grams. Volumes of
+// ingredients with unknown density are counted as water.
+func ToGrams(quantity float64, unitName, ingredient string) (float64, bool) {
+	from, ok := lookup(unitName)
+	if !ok {
+		return 0, false
+	}
+
+	grams := quantity * from.Size
+	if from.Kind == Volume {
+		if density, ok := Density(ingredient); ok {
+			grams *= density
+		}
+	}
+	return grams, true
+}
+
+// Add sums two quantities of the same ingredient. Quantities in different
+// units of the same kind are converted to the first unit before the sum is
+// normalized. ok is false when the units can't be added, e.g. grams and cups.
+func Add(quantity float64, unitName string, other float64, otherUnit string) (sum float64, name string, ok bool) {
+	if strings.EqualFold(strings.TrimSpace(unitName), strings.TrimSpace(otherUnit)) {
+		return quantity + other, unitName, true
+	}
+
+	from, ok := lookup(otherUnit)
+	if !ok {
+		return 0, "", false
+	}
+	to, ok := lookup(unitName)
+	if !ok || to.Kind != from.Kind {
+		return 0, "", false
+	}
+
+	sum, name = Normalize(quantity+other*from.Size/to.Size, to.Name)
+	return sum, name, true
+}
@@ -0,0 +1,130 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Homyakadze14/RecipeSite/internal/common"
+	"github.com/Homyakadze14/RecipeSite/internal/entities"
+)
+
+var (
+	ErrAlreadyBookmarked = errors.New("this recipe already bookmarked")
+	ErrNotBookmarked     = errors.New("this recipe not bookmarked yet")
+)
+
+type bookmarkStorage interface {
+	IsBookmarked(ctx context.Context, bookmark *entities.Bookmark) (bool, error)
+	Bookmark(ctx context.Context, bookmark *entities.Bookmark) error
+	Unbookmark(ctx context.Context, bookmark *entities.Bookmark) error
+	GetRecipes(ctx context.Context, userID int, page *entities.RecipePage) ([]entities.Recipe, error)
+}
+
+type userUseCaseForBookmark interface {
+	GetByLogin(ctx context.Context, login string) (*entities.User, error)
+	GetAuthors(ctx context.Context, ids []int) (map[int]*entities.Author, error)
+}
+
+type scaleUseCaseForBookmark interface {
+	ConvertRecipe(recipe *entities.RecipeWithAuthor, system string)
+}
+
+type BookmarkUseCase struct {
+	storage      bookmarkStorage
+	userUseCase  userUseCaseForBookmark
+	scaleUseCase scaleUseCaseForBookmark
+}
+
+func NewBookmarkUseCase(st bookmarkStorage, uu userUseCaseForBookmark, scu scaleUseCaseForBookmark) *BookmarkUseCase {
+	return &BookmarkUseCase{
+		storage:      st,
+		userUseCase:  uu,
+		scaleUseCase: scu,
+	}
+}
+
+func (u *BookmarkUseCase) IsBookmarked(ctx context.Context, bookmark *entities.Bookmark) (bool, error) {
+	bookmarked, err := u.storage.IsBookmarked(ctx, bookmark)
+	if err != nil {
+		return false, fmt.Errorf("BookmarkUseCase - IsBookmarked - u.storage.IsBookmarked: %w", err)
+	}
+
+	return bookmarked, nil
+}
+
+func (u *BookmarkUseCase) Bookmark(ctx context.Context, bookmark *entities.Bookmark) error {
+	err := u.storage.Bookmark(ctx, bookmark)
+	if err != nil {
+		if errors.Is(err, ErrAlreadyBookmarked) {
+			return ErrAlreadyBookmarked
+		}
+		if errors.Is(err, ErrRecipeNotFound) {
+			return ErrRecipeNotFound
+		}
+		return fmt.Errorf("BookmarkUseCase - Bookmark - u.storage.Bookmark: %w", err)
+	}
+
+	return nil
+}
+
+func (u *BookmarkUseCase) Unbookmark(ctx context.Context, bookmark *entities.Bookmark) error {
+	err := u.storage.Unbookmark(ctx, bookmark)
+	if err != nil {
+		if errors.Is(err, ErrNotBookmarked) {
+			return ErrNotBookmarked
+		}
+		return fmt.Errorf("BookmarkUseCase - Unbookmark - u.storage.Unbookmark: %w", err)
+	}
+
+	return nil
+}
+
+// GetRecipes gets a page of recipes the user saved for later. Bookmarks are
+// private, so only the user may get them.
+func (u *BookmarkUseCase) GetRecipes(ctx context.Context, login string, ownerID int, page *entities.RecipePage) (*entities.RecipeList, error) {
+	user, err := u.userUseCase.GetByLogin(ctx, login)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("BookmarkUseCase - GetRecipes - u.userUseCase.GetByLogin: %w", err)
+	}
+
+	if !common.HavePermisson(ownerID, user.ID) {
+		return nil, common.ErrNoPermissions
+	}
+
+	recipes, err := u.storage.GetRecipes(ctx, user.ID, page)
+	if err != nil {
+		if errors.Is(err, ErrBadCursor) {
+			return nil, ErrBadCursor
+		}
+		return nil, fmt.Errorf("BookmarkUseCase - GetRecipes - u.storage.GetRecipes: %w", err)
+	}
+
+	ids := make([]int, 0, len(recipes))
+	for _, recipe := range recipes {
+		ids = append(ids, recipe.UserID)
+	}
+
+	authors, err := u.userUseCase.GetAuthors(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("BookmarkUseCase - GetRecipes - u.userUseCase.GetAuthors: %w", err)
+	}
+
+	list := &entities.RecipeList{Recipes: make([]entities.RecipeWithAuthor, 0, len(recipes))}
+	for _, recipe := range recipes {
+		rc := recipe.ToRecipeWithAuthor()
+		rc.Author = authors[recipe.UserID]
+		if page.Units != "" {
+			u.scaleUseCase.ConvertRecipe(rc, page.Units)
+		}
+		list.Recipes = append(list.Recipes, *rc)
+	}
+
+	if len(recipes) == page.Limit {
+		list.NextCursor = recipes[len(recipes)-1].Cursor
+	}
+	return list, nil
+}
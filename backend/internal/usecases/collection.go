@@ -0,0 +1,264 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/Homyakadze14/RecipeSite/internal/common"
+	"github.com/Homyakadze14/RecipeSite/internal/entities"
+)
+
+var (
+	ErrCollectionNotFound    = errors.New("collection not found")
+	ErrRecipeInCollection    = errors.New("recipe is already in the collection")
+	ErrRecipeNotInCollection = errors.New("recipe is not in the collection")
+	ErrCollectionOrder       = errors.New("recipe_ids must list every recipe of the collection once")
+)
+
+type collectionStorage interface {
+	GetAll(ctx context.Context, userID int, hidden bool) ([]entities.Collection, error)
+	Get(ctx context.Context, id int) (*entities.Collection, error)
+	Create(ctx context.Context, collection *entities.Collection) (id int, err error)
+	Update(ctx context.Context, collection *entities.Collection) error
+	Delete(ctx context.Context, id int) error
+	GetRecipes(ctx context.Context, collectionID, viewerID int) ([]entities.RecipeWithAuthor, error)
+	AddRecipe(ctx context.Context, collection *entities.Collection, item *entities.CollectionRecipe) error
+	RemoveRecipe(ctx context.Context, collectionID, recipeID int) error
+	Reorder(ctx context.Context, collection *entities.Collection, ids []int) error
+}
+
+type userUseCaseForCollection interface {
+	GetByLogin(ctx context.Context, login string) (*entities.User, error)
+}
+
+type CollectionUseCase struct {
+	storage     collectionStorage
+	userUseCase userUseCaseForCollection
+	fileStorage fileStorage
+}
+
+func NewCollectionUseCase(st collectionStorage, uu userUseCaseForCollection, fs fileStorage) *CollectionUseCase {
+	return &CollectionUseCase{
+		storage:     st,
+		userUseCase: uu,
+		fileStorage: fs,
+	}
+}
+
+func (u *CollectionUseCase) getUser(ctx context.Context, login string) (*entities.User, error) {
+	user, err := u.userUseCase.GetByLogin(ctx, login)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("CollectionUseCase - getUser - u.userUseCase.GetByLogin: %w", err)
+	}
+	return user, nil
+}
+
+// getCollection gets the collection of the user if the viewer may see it.
+func (u *CollectionUseCase) getCollection(ctx context.Context, user *entities.User, viewerID, id int) (*entities.Collection, error) {
+	collection, err := u.storage.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, ErrCollectionNotFound) {
+			return nil, ErrCollectionNotFound
+		}
+		return nil, fmt.Errorf("CollectionUseCase - getCollection - u.storage.Get: %w", err)
+	}
+
+	if collection.UserID != user.ID || !collection.IsVisibleTo(viewerID) {
+		return nil, ErrCollectionNotFound
+	}
+	return collection, nil
+}
+
+// getOwnCollection gets the collection if the owner may change it.
+func (u *CollectionUseCase) getOwnCollection(ctx context.Context, login string, ownerID, id int) (*entities.Collection, error) {
+	user, err := u.getUser(ctx, login)
+	if err != nil {
+		return nil, fmt.Errorf("CollectionUseCase - getOwnCollection - u.getUser: %w", err)
+	}
+
+	if !common.HavePermisson(ownerID, user.ID) {
+		return nil, common.ErrNoPermissions
+	}
+
+	return u.getCollection(ctx, user, ownerID, id)
+}
+
+// GetAll gets collections of the user, private ones are shown to the owner only.
+func (u *CollectionUseCase) GetAll(ctx context.Context, login string, viewerID int) ([]entities.Collection, error) {
+	user, err := u.getUser(ctx, login)
+	if err != nil {
+		return nil, fmt.Errorf("CollectionUseCase - GetAll - u.getUser: %w", err)
+	}
+
+	collections, err := u.storage.GetAll(ctx, user.ID, common.HavePermisson(viewerID, user.ID))
+	if err != nil {
+		return nil, fmt.Errorf("CollectionUseCase - GetAll - u.storage.GetAll: %w", err)
+	}
+	return collections, nil
+}
+
+func (u *CollectionUseCase) Get(ctx context.Context, login string, viewerID, id int) (*entities.FullCollection, error) {
+	user, err := u.getUser(ctx, login)
+	if err != nil {
+		return nil, fmt.Errorf("CollectionUseCase - Get - u.getUser: %w", err)
+	}
+
+	collection, err := u.getCollection(ctx, user, viewerID, id)
+	if err != nil {
+		return nil, fmt.Errorf("CollectionUseCase - Get - u.getCollection: %w", err)
+	}
+
+	recipes, err := u.storage.GetRecipes(ctx, collection.ID, viewerID)
+	if err != nil {
+		return nil, fmt.Errorf("CollectionUseCase - Get - u.storage.GetRecipes: %w", err)
+	}
+
+	return &entities.FullCollection{Collection: collection, Recipes: recipes}, nil
+}
+
+func (u *CollectionUseCase) Create(ctx context.Context, login string, ownerID int, params *entities.CreateCollection) (int, error) {
+	user, err := u.getUser(ctx, login)
+	if err != nil {
+		return -1, fmt.Errorf("CollectionUseCase - Create - u.getUser: %w", err)
+	}
+
+	if !common.HavePermisson(ownerID, user.ID) {
+		return -1, common.ErrNoPermissions
+	}
+
+	collection := params.ToCollection()
+	collection.UserID = user.ID
+
+	if params.Cover != nil {
+		collection.CoverURL, err = u.fileStorage.Save([]io.ReadSeeker{params.Cover}, "image/jpeg")
+		if err != nil {
+			return -1, fmt.Errorf("CollectionUseCase - Create - u.fileStorage.Save: %w", err)
+		}
+	}
+
+	id, err := u.storage.Create(ctx, collection)
+	if err != nil {
+		storageErr := fmt.Errorf("CollectionUseCase - Create - u.storage.Create: %w", err)
+
+		err := u.fileStorage.Remove(collection.CoverURL)
+		if err != nil {
+			return -1, fmt.Errorf("%w; CollectionUseCase - Create - u.fileStorage.Remove: %w", storageErr, err)
+		}
+
+		return -1, storageErr
+	}
+
+	return id, nil
+}
+
+func (u *CollectionUseCase) Update(ctx context.Context, login string, ownerID, id int, params *entities.UpdateCollection) error {
+	collection, err := u.getOwnCollection(ctx, login, ownerID, id)
+	if err != nil {
+		return fmt.Errorf("CollectionUseCase - Update - u.getOwnCollection: %w", err)
+	}
+
+	params.UpdateValues(collection)
+
+	oldCoverURL := ""
+	if params.Cover != nil {
+		url, err := u.fileStorage.Save([]io.ReadSeeker{params.Cover}, "image/jpeg")
+		if err != nil {
+			return fmt.Errorf("CollectionUseCase - Update - u.fileStorage.Save: %w", err)
+		}
+		oldCoverURL = collection.CoverURL
+		collection.CoverURL = url
+	}
+
+	err = u.storage.Update(ctx, collection)
+	if err != nil {
+		storageErr := fmt.Errorf("CollectionUseCase - Update - u.storage.Update: %w", err)
+
+		if params.Cover != nil {
+			err := u.fileStorage.Remove(collection.CoverURL)
+			if err != nil {
+				return fmt.Errorf("%w; CollectionUseCase - Update - u.fileStorage.Remove: %w", storageErr, err)
+			}
+		}
+
+		return storageErr
+	}
+
+	if oldCoverURL != "" {
+		err = u.fileStorage.Remove(oldCoverURL)
+		if err != nil {
+			return fmt.Errorf("CollectionUseCase - Update - u.fileStorage.Remove: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (u *CollectionUseCase) Delete(ctx context.Context, login string, ownerID, id int) error {
+	collection, err := u.getOwnCollection(ctx, login, ownerID, id)
+	if err != nil {
+		return fmt.Errorf("CollectionUseCase - Delete - u.getOwnCollection: %w", err)
+	}
+
+	err = u.storage.Delete(ctx, collection.ID)
+	if err != nil {
+		return fmt.Errorf("CollectionUseCase - Delete - u.storage.Delete: %w", err)
+	}
+
+	if collection.CoverURL != "" {
+		err = u.fileStorage.Remove(collection.CoverURL)
+		if err != nil {
+			return fmt.Errorf("CollectionUseCase - Delete - u.fileStorage.Remove: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (u *CollectionUseCase) AddRecipe(ctx context.Context, login string, ownerID, id int, item *entities.CollectionRecipe) error {
+	collection, err := u.getOwnCollection(ctx, login, ownerID, id)
+	if err != nil {
+		return fmt.Errorf("CollectionUseCase - AddRecipe - u.getOwnCollection: %w", err)
+	}
+
+	err = u.storage.AddRecipe(ctx, collection, item)
+	if err != nil {
+		return fmt.Errorf("CollectionUseCase - AddRecipe - u.storage.AddRecipe: %w", err)
+	}
+	return nil
+}
+
+func (u *CollectionUseCase) RemoveRecipe(ctx context.Context, login string, ownerID, id, recipeID int) error {
+	collection, err := u.getOwnCollection(ctx, login, ownerID, id)
+	if err != nil {
+		return fmt.Errorf("CollectionUseCase - RemoveRecipe - u.getOwnCollection: %w", err)
+	}
+
+	err = u.storage.RemoveRecipe(ctx, collection.ID, recipeID)
+	if err != nil {
+		return fmt.Errorf("CollectionUseCase - RemoveRecipe - u.storage.RemoveRecipe: %w", err)
+	}
+	return nil
+}
+
+// Reorder changes the order of recipes in the collection. The new order must
+// contain every recipe of the collection the owner sees exactly once.
+func (u *CollectionUseCase) Reorder(ctx context.Context, login string, ownerID, id int, order *entities.CollectionOrder) error {
+	collection, err := u.getOwnCollection(ctx, login, ownerID, id)
+	if err != nil {
+		return fmt.Errorf("CollectionUseCase - Reorder - u.getOwnCollection: %w", err)
+	}
+
+	err = u.storage.Reorder(ctx, collection, order.RecipeIDs)
+	if err != nil {
+		if errors.Is(err, ErrCollectionOrder) {
+			return ErrCollectionOrder
+		}
+		return fmt.Errorf("CollectionUseCase - Reorder - u.storage.Reorder: %w", err)
+	}
+	return nil
+}
@@ -22,7 +22,7 @@ type commentStorage interface {
 }
 
 type userUseCaseForComment interface {
-	GetAuthor(ctx context.Context, id int) (*entities.Author, error)
+	GetAuthors(ctx context.Context, ids []int) (map[int]*entities.Author, error)
 }
 
 type CommentUseCase struct {
@@ -88,11 +88,18 @@ func (u *CommentUseCase) GetAll(ctx context.Context, recipeID int) ([]entities.C
 		return nil, fmt.Errorf("CommentUseCase - GetAll - u.storage.GetAll: %w", err)
 	}
 
+	ids := make([]int, 0, len(comments))
 	for i := range comments {
-		comments[i].Author, err = u.userUseCase.GetAuthor(ctx, comments[i].UserID)
-		if err != nil {
-			return nil, fmt.Errorf("CommentUseCase - GetAll - u.userUseCase.GetAuthor: %w", err)
-		}
+		ids = append(ids, comments[i].UserID)
+	}
+
+	authors, err := u.userUseCase.GetAuthors(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("CommentUseCase - GetAll - u.userUseCase.GetAuthors: %w", err)
+	}
+
+	for i := range comments {
+		comments[i].Author = authors[comments[i].UserID]
 	}
 
 	return comments, nil
@@ -0,0 +1,189 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/Homyakadze14/RecipeSite/internal/common"
+	"github.com/Homyakadze14/RecipeSite/internal/entities"
+)
+
+// recipeGallerySize is the count of the latest cook photos shown on a recipe.
+const recipeGallerySize = 12
+
+var (
+	ErrCookLogNotFound = errors.New("cook log not found")
+	ErrCookedInFuture  = errors.New("cooked_on must not be in the future")
+)
+
+type cookLogStorage interface {
+	Get(ctx context.Context, id int) (*entities.CookLog, error)
+	Create(ctx context.Context, log *entities.CookLog, cookedOn time.Time) (id int, err error)
+	Delete(ctx context.Context, id int) error
+	CookedCount(ctx context.Context, recipeID int) (int, error)
+	GetPhotos(ctx context.Context, recipeID, limit int) ([]entities.CookLog, error)
+}
+
+type recipeStorageForCookLog interface {
+	Get(ctx context.Context, id int) (*entities.Recipe, error)
+}
+
+type userUseCaseForCookLog interface {
+	GetByLogin(ctx context.Context, login string) (*entities.User, error)
+}
+
+type CookLogUseCase struct {
+	storage       cookLogStorage
+	recipeStorage recipeStorageForCookLog
+	userUseCase   userUseCaseForCookLog
+	fileStorage   fileStorage
+}
+
+func NewCookLogUseCase(st cookLogStorage, rst recipeStorageForCookLog, uu userUseCaseForCookLog, fs fileStorage) *CookLogUseCase {
+	return &CookLogUseCase{
+		storage:       st,
+		recipeStorage: rst,
+		userUseCase:   uu,
+		fileStorage:   fs,
+	}
+}
+
+// getRecipe gets the recipe if the user may see it.
+func (u *CookLogUseCase) getRecipe(ctx context.Context, recipeID, userID int) (*entities.Recipe, error) {
+	recipe, err := u.recipeStorage.Get(ctx, recipeID)
+	if err != nil {
+		if errors.Is(err, ErrRecipeNotFound) {
+			return nil, ErrRecipeNotFound
+		}
+		return nil, fmt.Errorf("CookLogUseCase - getRecipe - u.recipeStorage.Get: %w", err)
+	}
+
+	if !recipe.IsVisibleTo(userID) {
+		return nil, ErrRecipeNotFound
+	}
+	return recipe, nil
+}
+
+func (u *CookLogUseCase) CookedCount(ctx context.Context, recipeID int) (int, error) {
+	count, err := u.storage.CookedCount(ctx, recipeID)
+	if err != nil {
+		return 0, fmt.Errorf("CookLogUseCase - CookedCount - u.storage.CookedCount: %w", err)
+	}
+	return count, nil
+}
+
+// GetGallery gets the latest cook photos shown on the recipe.
+func (u *CookLogUseCase) GetGallery(ctx context.Context, recipeID int) ([]entities.CookLog, error) {
+	logs, err := u.storage.GetPhotos(ctx, recipeID, recipeGallerySize)
+	if err != nil {
+		return nil, fmt.Errorf("CookLogUseCase - GetGallery - u.storage.GetPhotos: %w", err)
+	}
+	return logs, nil
+}
+
+// GetPhotos gets all the cook photos of the recipe the user may see.
+func (u *CookLogUseCase) GetPhotos(ctx context.Context, recipeID, userID int) ([]entities.CookLog, error) {
+	_, err := u.getRecipe(ctx, recipeID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("CookLogUseCase - GetPhotos - u.getRecipe: %w", err)
+	}
+
+	logs, err := u.storage.GetPhotos(ctx, recipeID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("CookLogUseCase - GetPhotos - u.storage.GetPhotos: %w", err)
+	}
+	return logs, nil
+}
+
+// Create marks the recipe as cooked by the user.
+func (u *CookLogUseCase) Create(ctx context.Context, recipeID, userID int, params *entities.CreateCookLog) (int, error) {
+	recipe, err := u.getRecipe(ctx, recipeID, userID)
+	if err != nil {
+		return -1, fmt.Errorf("CookLogUseCase - Create - u.getRecipe: %w", err)
+	}
+
+	cookedOn := time.Now()
+	if params.CookedOn != "" {
+		cookedOn, err = time.Parse(entities.DateLayout, params.CookedOn)
+		if err != nil {
+			return -1, fmt.Errorf("CookLogUseCase - Create - time.Parse: %w", err)
+		}
+		if cookedOn.After(time.Now()) {
+			return -1, ErrCookedInFuture
+		}
+	}
+
+	log := &entities.CookLog{
+		UserID:   userID,
+		RecipeID: recipe.ID,
+		Note:     params.Note,
+	}
+
+	if params.Photo != nil {
+		url, err := u.fileStorage.Save([]io.ReadSeeker{params.Photo}, "image/jpeg")
+		if err != nil {
+			return -1, fmt.Errorf("CookLogUseCase - Create - u.fileStorage.Save: %w", err)
+		}
+		log.PhotoURL = strings.TrimSuffix(url, ";")
+	}
+
+	id, err := u.storage.Create(ctx, log, cookedOn)
+	if err != nil {
+		storageErr := fmt.Errorf("CookLogUseCase - Create - u.storage.Create: %w", err)
+
+		if log.PhotoURL != "" {
+			err := u.fileStorage.Remove(log.PhotoURL)
+			if err != nil {
+				return -1, fmt.Errorf("%w; CookLogUseCase - Create - u.fileStorage.Remove: %w", storageErr, err)
+			}
+		}
+
+		return -1, storageErr
+	}
+
+	return id, nil
+}
+
+func (u *CookLogUseCase) Delete(ctx context.Context, login string, ownerID, id int) error {
+	user, err := u.userUseCase.GetByLogin(ctx, login)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("CookLogUseCase - Delete - u.userUseCase.GetByLogin: %w", err)
+	}
+
+	if !common.HavePermisson(ownerID, user.ID) {
+		return common.ErrNoPermissions
+	}
+
+	log, err := u.storage.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, ErrCookLogNotFound) {
+			return ErrCookLogNotFound
+		}
+		return fmt.Errorf("CookLogUseCase - Delete - u.storage.Get: %w", err)
+	}
+
+	if !common.HavePermisson(log.UserID, user.ID) {
+		return ErrCookLogNotFound
+	}
+
+	err = u.storage.Delete(ctx, log.ID)
+	if err != nil {
+		return fmt.Errorf("CookLogUseCase - Delete - u.storage.Delete: %w", err)
+	}
+
+	if log.PhotoURL != "" {
+		err = u.fileStorage.Remove(log.PhotoURL)
+		if err != nil {
+			return fmt.Errorf("CookLogUseCase - Delete - u.fileStorage.Remove: %w", err)
+		}
+	}
+
+	return nil
+}
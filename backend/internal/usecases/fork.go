@@ -0,0 +1,51 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/Homyakadze14/RecipeSite/internal/entities"
+)
+
+var ErrForkOwnRecipe = errors.New("you can't fork your own recipe")
+
+// Fork copies the recipe into the account of the user as a draft and tells
+// the author of the original recipe about it.
+func (r *RecipeUseCases) Fork(ctx context.Context, userID, id int) (forkID int, err error) {
+	recipe, err := r.GetRecipe(ctx, id, userID)
+	if err != nil {
+		return -1, fmt.Errorf("RecipeUseCase - Fork - r.GetRecipe: %w", err)
+	}
+
+	if recipe.UserID == userID {
+		return -1, ErrForkOwnRecipe
+	}
+
+	forker, err := r.GetRecipeAuthor(ctx, userID)
+	if err != nil {
+		return -1, fmt.Errorf("RecipeUseCase - Fork - r.GetRecipeAuthor: %w", err)
+	}
+
+	forkID, err = r.storage.Save(ctx, recipe.Fork(userID))
+	if err != nil {
+		return -1, fmt.Errorf("RecipeUseCase - Fork - r.storage.Save: %w", err)
+	}
+
+	message := &entities.RecipeForkMsg{
+		AuthorID:    recipe.UserID,
+		RecipeID:    recipe.ID,
+		ForkID:      forkID,
+		ForkerLogin: forker.Login,
+	}
+
+	go func() {
+		err := r.subscribeUseCase.SendForkToMsgBroker(ctx, message)
+		if err != nil {
+			slog.Error(fmt.Sprintf("RecipeUseCase - Fork - r.subscribeUseCase.SendForkToMsgBroker: %s", err.Error()))
+		}
+	}()
+
+	return forkID, nil
+}
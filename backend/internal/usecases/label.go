@@ -0,0 +1,135 @@
+package usecases
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/Homyakadze14/RecipeSite/internal/entities"
+)
+
+// allergenKeywords are words in ingredient names which reveal an allergen.
+// English words match in plural too, Russian ones are listed in the forms
+// used in recipes, since a beginning of a word matches unrelated ingredients.
+var allergenKeywords = map[string][]string{
+	"gluten": {"flour", "wheat", "bread", "breadcrumb", "pasta", "spaghetti", "macaroni", "barley", "rye", "semolina",
+		"couscous", "noodle", "мука", "муки", "муку", "пшеница", "пшеницы", "пшеничная", "пшеничной", "пшеничный",
+		"хлеб", "хлеба", "хлебные", "макароны", "макарон", "спагетти", "ячмень", "ячменя", "ячменная", "рожь",
+		"ржаная", "ржаной", "ржаные", "манка", "манки", "манная", "манной", "кускус", "кускуса", "лапша", "лапши"},
+	"dairy": {"milk", "butter", "buttermilk", "cheese", "cream", "yogurt", "yoghurt", "kefir", "parmesan", "mozzarella",
+		"молоко", "молока", "молочный", "молочные", "сливочное", "сливочного", "сыр", "сыра", "сливки", "сливок",
+		"сметана", "сметаны", "творог", "творога", "йогурт", "йогурта", "кефир", "кефира", "пармезан", "моцарелла"},
+	"eggs": {"egg", "yolk", "яйцо", "яйца", "яиц", "яичный", "яичные", "яичных", "желток", "желтка", "желтки", "желтков"},
+	"nuts": {"walnut", "almond", "hazelnut", "cashew", "pistachio", "pecan", "орех", "ореха", "орехи", "орехов",
+		"миндаль", "миндаля", "миндальная", "миндальное", "фундук", "фундука", "кешью", "фисташки", "фисташек"},
+	"peanuts": {"peanut", "арахис", "арахиса", "арахисовое", "арахисовая", "арахисовый"},
+	"soy":     {"soy", "soya", "tofu", "соя", "сои", "соевый", "соевое", "соевая", "соевого", "тофу"},
+	"fish": {"fish", "salmon", "tuna", "cod", "anchovy", "anchovies", "herring", "рыба", "рыбы", "рыбу", "рыбный",
+		"лосось", "лосося", "семга", "сёмга", "семги", "тунец", "тунца", "треска", "трески", "сельдь", "сельди",
+		"анчоус", "анчоусы", "анчоусов"},
+	"shellfish": {"shrimp", "prawn", "crab", "lobster", "mussel", "oyster", "squid", "креветка", "креветки", "креветок",
+		"краб", "краба", "крабовые", "мидии", "мидий", "устрицы", "устриц", "кальмар", "кальмара", "кальмары", "кальмаров"},
+	"sesame":  {"sesame", "tahini", "кунжут", "кунжута", "кунжутное", "кунжутная", "тахини"},
+	"celery":  {"celery", "сельдерей", "сельдерея"},
+	"mustard": {"mustard", "горчица", "горчицы", "горчичный"},
+}
+
+var (
+	meatKeywords = []string{"beef", "pork", "chicken", "lamb", "turkey", "duck", "bacon", "ham", "sausage", "meat",
+		"mince", "minced", "steak", "говядина", "говядины", "свинина", "свинины", "курица", "курицы", "куриное",
+		"куриная", "куриный", "куриные", "баранина", "баранины", "индейка", "индейки", "утка", "утки", "бекон",
+		"бекона", "ветчина", "ветчины", "колбаса", "колбасы", "сосиски", "сосисок", "мясо", "мяса", "мясной", "фарш", "фарша"}
+	honeyKeywords = []string{"honey", "мед", "мёд", "меда", "мёда", "медовый"}
+	// plantQualifiers make the dairy word after them plant based: peanut butter,
+	// coconut milk and the like.
+	plantQualifiers = []string{"peanut", "almond", "cashew", "cocoa", "coconut", "shea", "soy", "oat", "rice",
+		"арахисовое", "арахисовая", "миндальное", "кокосовое", "кокосовые", "кокосовых", "соевое", "овсяное", "рисовое"}
+)
+
+type LabelUseCase struct{}
+
+func NewLabelUseCase() *LabelUseCase {
+	return &LabelUseCase{}
+}
+
+func (u *LabelUseCase) GetAll() *entities.Labels {
+	return &entities.Labels{
+		Allergens: entities.Allergens,
+		Diets:     entities.Diets,
+	}
+}
+
+// Suggest guesses labels by ingredient names. It is only a hint for the
+// author: halal and kosher can't be told by ingredients and are never suggested.
+func (u *LabelUseCase) Suggest(ingredients []entities.Ingredient) *entities.Labels {
+	labels := &entities.Labels{
+		Allergens: make([]string, 0, len(entities.Allergens)),
+		Diets:     make([]string, 0, len(entities.Diets)),
+	}
+
+	words := make([]string, 0, len(ingredients)*2)
+	dairyWords := make([]string, 0, len(ingredients)*2)
+	for _, ingredient := range ingredients {
+		name := strings.FieldsFunc(strings.ToLower(ingredient.Name), func(r rune) bool {
+			return !unicode.IsLetter(r)
+		})
+		words = append(words, name...)
+		for i, word := range name {
+			if i == 0 || !slices.Contains(plantQualifiers, name[i-1]) {
+				dairyWords = append(dairyWords, word)
+			}
+		}
+	}
+
+	for _, allergen := range entities.Allergens {
+		found := hasKeyword(words, allergenKeywords[allergen])
+		if allergen == "dairy" {
+			found = hasKeyword(dairyWords, allergenKeywords[allergen])
+		}
+		if found {
+			labels.Allergens = append(labels.Allergens, allergen)
+		}
+	}
+
+	has := func(allergen string) bool {
+		return slices.Contains(labels.Allergens, allergen)
+	}
+	meat := hasKeyword(words, meatKeywords)
+	vegetarian := !meat && !has("fish") && !has("shellfish")
+
+	if vegetarian && !has("dairy") && !has("eggs") && !hasKeyword(words, honeyKeywords) {
+		labels.Diets = append(labels.Diets, "vegan")
+	}
+	if vegetarian {
+		labels.Diets = append(labels.Diets, "vegetarian")
+	}
+	if !meat {
+		labels.Diets = append(labels.Diets, "pescatarian")
+	}
+	if !has("gluten") {
+		labels.Diets = append(labels.Diets, "gluten-free")
+	}
+	if !has("dairy") {
+		labels.Diets = append(labels.Diets, "lactose-free")
+	}
+
+	return labels
+}
+
+// hasKeyword tells if any of the words is one of the keywords or its
+// English plural.
+func hasKeyword(words, keywords []string) bool {
+	for _, word := range words {
+		for _, keyword := range keywords {
+			if isWordForm(word, keyword) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isWordForm reports whether the word is the keyword or its English plural.
+func isWordForm(word, keyword string) bool {
+	return word == keyword || word == keyword+"s" || word == keyword+"es"
+}
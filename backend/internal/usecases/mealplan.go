@@ -0,0 +1,187 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Homyakadze14/RecipeSite/internal/common"
+	"github.com/Homyakadze14/RecipeSite/internal/entities"
+)
+
+// maxMealPlanDays is the longest range of days the plan is shown for.
+const maxMealPlanDays = 62
+
+var (
+	ErrMealPlanEntryNotFound = errors.New("meal plan entry not found")
+	ErrMealAlreadyPlanned    = errors.New("this recipe is already planned for the meal")
+	ErrMealPlanRange         = fmt.Errorf("to must not be before from and the range must be at most %v days", maxMealPlanDays)
+	ErrMealPlanCopy          = errors.New("the week can be copied only to a later week which doesn't overlap it")
+)
+
+type mealPlanStorage interface {
+	Get(ctx context.Context, userID int, from, to time.Time) ([]entities.MealPlanEntry, error)
+	GetEntry(ctx context.Context, userID, id int) (*entities.MealPlanEntry, error)
+	Add(ctx context.Context, userID int, date time.Time, params *entities.AddMealPlanEntry) (id int, err error)
+	Update(ctx context.Context, userID int, date time.Time, entry *entities.MealPlanEntry) error
+	Delete(ctx context.Context, userID, id int) error
+	CopyWeek(ctx context.Context, userID int, from, to time.Time) (copied int, err error)
+}
+
+type userUseCaseForMealPlan interface {
+	GetByLogin(ctx context.Context, login string) (*entities.User, error)
+}
+
+type MealPlanUseCase struct {
+	storage     mealPlanStorage
+	userUseCase userUseCaseForMealPlan
+}
+
+func NewMealPlanUseCase(st mealPlanStorage, uu userUseCaseForMealPlan) *MealPlanUseCase {
+	return &MealPlanUseCase{
+		storage:     st,
+		userUseCase: uu,
+	}
+}
+
+// getOwner gets the user if the meal plan is the owner's, plans are private.
+func (u *MealPlanUseCase) getOwner(ctx context.Context, login string, ownerID int) (*entities.User, error) {
+	user, err := u.userUseCase.GetByLogin(ctx, login)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("MealPlanUseCase - getOwner - u.userUseCase.GetByLogin: %w", err)
+	}
+
+	if !common.HavePermisson(ownerID, user.ID) {
+		return nil, common.ErrNoPermissions
+	}
+	return user, nil
+}
+
+// Get gets the plan for the days from from to to inclusive.
+func (u *MealPlanUseCase) Get(ctx context.Context, login string, ownerID int, params *entities.MealPlanRange) (*entities.MealPlan, error) {
+	user, err := u.getOwner(ctx, login, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("MealPlanUseCase - Get - u.getOwner: %w", err)
+	}
+
+	from, err := time.Parse(entities.DateLayout, params.From)
+	if err != nil {
+		return nil, ErrMealPlanRange
+	}
+	to, err := time.Parse(entities.DateLayout, params.To)
+	if err != nil {
+		return nil, ErrMealPlanRange
+	}
+	if to.Before(from) || to.Sub(from) >= maxMealPlanDays*24*time.Hour {
+		return nil, ErrMealPlanRange
+	}
+
+	entries, err := u.storage.Get(ctx, user.ID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("MealPlanUseCase - Get - u.storage.Get: %w", err)
+	}
+
+	return entities.NewMealPlan(from, to, entries), nil
+}
+
+func (u *MealPlanUseCase) Add(ctx context.Context, login string, ownerID int, params *entities.AddMealPlanEntry) (int, error) {
+	user, err := u.getOwner(ctx, login, ownerID)
+	if err != nil {
+		return -1, fmt.Errorf("MealPlanUseCase - Add - u.getOwner: %w", err)
+	}
+
+	date, err := time.Parse(entities.DateLayout, params.Date)
+	if err != nil {
+		return -1, fmt.Errorf("MealPlanUseCase - Add - time.Parse: %w", err)
+	}
+
+	id, err := u.storage.Add(ctx, user.ID, date, params)
+	if err != nil {
+		if errors.Is(err, ErrRecipeNotFound) {
+			return -1, ErrRecipeNotFound
+		}
+		if errors.Is(err, ErrMealAlreadyPlanned) {
+			return -1, ErrMealAlreadyPlanned
+		}
+		return -1, fmt.Errorf("MealPlanUseCase - Add - u.storage.Add: %w", err)
+	}
+	return id, nil
+}
+
+func (u *MealPlanUseCase) Update(ctx context.Context, login string, ownerID, id int, params *entities.UpdateMealPlanEntry) error {
+	user, err := u.getOwner(ctx, login, ownerID)
+	if err != nil {
+		return fmt.Errorf("MealPlanUseCase - Update - u.getOwner: %w", err)
+	}
+
+	entry, err := u.storage.GetEntry(ctx, user.ID, id)
+	if err != nil {
+		if errors.Is(err, ErrMealPlanEntryNotFound) {
+			return ErrMealPlanEntryNotFound
+		}
+		return fmt.Errorf("MealPlanUseCase - Update - u.storage.GetEntry: %w", err)
+	}
+
+	params.UpdateValues(entry)
+
+	date, err := time.Parse(entities.DateLayout, entry.Date)
+	if err != nil {
+		return fmt.Errorf("MealPlanUseCase - Update - time.Parse: %w", err)
+	}
+
+	err = u.storage.Update(ctx, user.ID, date, entry)
+	if err != nil {
+		if errors.Is(err, ErrMealAlreadyPlanned) {
+			return ErrMealAlreadyPlanned
+		}
+		return fmt.Errorf("MealPlanUseCase - Update - u.storage.Update: %w", err)
+	}
+	return nil
+}
+
+func (u *MealPlanUseCase) Delete(ctx context.Context, login string, ownerID, id int) error {
+	user, err := u.getOwner(ctx, login, ownerID)
+	if err != nil {
+		return fmt.Errorf("MealPlanUseCase - Delete - u.getOwner: %w", err)
+	}
+
+	err = u.storage.Delete(ctx, user.ID, id)
+	if err != nil {
+		if errors.Is(err, ErrMealPlanEntryNotFound) {
+			return ErrMealPlanEntryNotFound
+		}
+		return fmt.Errorf("MealPlanUseCase - Delete - u.storage.Delete: %w", err)
+	}
+	return nil
+}
+
+// CopyWeek copies the week starting at params.From to a later week starting
+// at params.To. Meals already planned there are kept.
+func (u *MealPlanUseCase) CopyWeek(ctx context.Context, login string, ownerID int, params *entities.MealPlanCopy) (int, error) {
+	user, err := u.getOwner(ctx, login, ownerID)
+	if err != nil {
+		return 0, fmt.Errorf("MealPlanUseCase - CopyWeek - u.getOwner: %w", err)
+	}
+
+	from, err := time.Parse(entities.DateLayout, params.From)
+	if err != nil {
+		return 0, ErrMealPlanCopy
+	}
+	to, err := time.Parse(entities.DateLayout, params.To)
+	if err != nil {
+		return 0, ErrMealPlanCopy
+	}
+	if to.Before(from.AddDate(0, 0, 7)) {
+		return 0, ErrMealPlanCopy
+	}
+
+	copied, err := u.storage.CopyWeek(ctx, user.ID, from, to)
+	if err != nil {
+		return 0, fmt.Errorf("MealPlanUseCase - CopyWeek - u.storage.CopyWeek: %w", err)
+	}
+	return copied, nil
+}
@@ -0,0 +1,175 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Homyakadze14/RecipeSite/internal/common"
+	"github.com/Homyakadze14/RecipeSite/internal/entities"
+	"github.com/Homyakadze14/RecipeSite/internal/units"
+)
+
+var pieceUnits = []string{"", "pcs", "шт"}
+
+type nutritionStorage interface {
+	GetFoods(ctx context.Context) ([]entities.Food, error)
+}
+
+type nutritionCache interface {
+	Set(ctx context.Context, key string, value interface{}) error
+	Get(ctx context.Context, key string, dest interface{}) error
+	Del(ctx context.Context, key string) (res int64, err error)
+}
+
+type NutritionUseCase struct {
+	storage nutritionStorage
+	cache   nutritionCache
+}
+
+func NewNutritionUseCase(st nutritionStorage, cache nutritionCache) *NutritionUseCase {
+	return &NutritionUseCase{
+		storage: st,
+		cache:   cache,
+	}
+}
+
+func (u *NutritionUseCase) formCacheKey(recipeID int) string {
+	return fmt.Sprintf("recipe:%v:nutrition", recipeID)
+}
+
+// Get gets nutrition facts of the recipe from cache or calculates them.
+func (u *NutritionUseCase) Get(ctx context.Context, recipe *entities.Recipe) (*entities.Nutrition, error) {
+	nutrition := &entities.Nutrition{}
+	err := u.cache.Get(ctx, u.formCacheKey(recipe.ID), nutrition)
+	if err == nil {
+		return nutrition, nil
+	}
+	if !errors.Is(err, common.ErrCacheKeyNotFound) {
+		return nil, fmt.Errorf("NutritionUseCase - Get - u.cache.Get: %w", err)
+	}
+
+	nutrition, err = u.Refresh(ctx, recipe)
+	if err != nil {
+		return nil, fmt.Errorf("NutritionUseCase - Get - u.Refresh: %w", err)
+	}
+	return nutrition, nil
+}
+
+// Refresh calculates nutrition facts of the recipe and caches them.
+func (u *NutritionUseCase) Refresh(ctx context.Context, recipe *entities.Recipe) (*entities.Nutrition, error) {
+	foods, err := u.storage.GetFoods(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("NutritionUseCase - Refresh - u.storage.GetFoods: %w", err)
+	}
+
+	nutrition := u.Calculate(recipe.Ingredients, recipe.Servings, foods)
+
+	err = u.cache.Set(ctx, u.formCacheKey(recipe.ID), nutrition)
+	if err != nil {
+		return nil, fmt.Errorf("NutritionUseCase - Refresh - u.cache.Set: %w", err)
+	}
+	return nutrition, nil
+}
+
+func (u *NutritionUseCase) Delete(ctx context.Context, recipeID int) error {
+	_, err := u.cache.Del(ctx, u.formCacheKey(recipeID))
+	if err != nil {
+		return fmt.Errorf("NutritionUseCase - Delete - u.cache.Del: %w", err)
+	}
+	return nil
+}
+
+// Calculate sums nutrition facts of the ingredients found in foods.
+func (u *NutritionUseCase) Calculate(ingredients []entities.Ingredient, servings int, foods []entities.Food) *entities.Nutrition {
+	nutrition := &entities.Nutrition{Ingredients: len(ingredients)}
+	for _, ingredient := range ingredients {
+		food := matchFood(ingredient.Name, foods)
+		if food == nil {
+			continue
+		}
+
+		grams, ok := ingredientGrams(&ingredient, food)
+		if !ok {
+			continue
+		}
+
+		nutrition.MatchedIngredients++
+		nutrition.Total.Calories += food.Facts.Calories * grams / 100
+		nutrition.Total.Protein += food.Facts.Protein * grams / 100
+		nutrition.Total.Fat += food.Facts.Fat * grams / 100
+		nutrition.Total.Carbs += food.Facts.Carbs * grams / 100
+	}
+
+	if servings < 1 {
+		servings = 1
+	}
+	nutrition.PerServing = scaleFacts(nutrition.Total, 1/float64(servings))
+	nutrition.Total = scaleFacts(nutrition.Total, 1)
+	return nutrition
+}
+
+// matchFood finds the food with the longest keyword found among the words of
+// the name, so "eggplant" isn't an egg and "sour cream" isn't a cream.
+func matchFood(name string, foods []entities.Food) *entities.Food {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+
+	var match *entities.Food
+	longest := 0
+	for i := range foods {
+		for _, keyword := range foods[i].Keywords {
+			length := utf8.RuneCountInString(keyword)
+			if length > longest && hasPhrase(words, strings.Fields(keyword)) {
+				match, longest = &foods[i], length
+			}
+		}
+	}
+	return match
+}
+
+// hasPhrase reports whether the words of the phrase follow each other in
+// words. The last word of the phrase may be in the plural.
+func hasPhrase(words, phrase []string) bool {
+	if len(phrase) == 0 {
+		return false
+	}
+	last := len(phrase) - 1
+	for start := 0; start+last < len(words); start++ {
+		if slices.Equal(words[start:start+last], phrase[:last]) && isWordForm(words[start+last], phrase[last]) {
+			return true
+		}
+	}
+	return false
+}
+
+func ingredientGrams(ingredient *entities.Ingredient, food *entities.Food) (float64, bool) {
+	for _, unit := range pieceUnits {
+		if strings.ToLower(ingredient.Unit) == unit {
+			if food.PieceWeight == 0 || ingredient.Quantity == 0 {
+				return 0, false
+			}
+			return ingredient.Quantity * food.PieceWeight, true
+		}
+	}
+	return units.ToGrams(ingredient.Quantity, ingredient.Unit, ingredient.Name)
+}
+
+// scaleFacts multiplies the facts by k and rounds them for display.
+func scaleFacts(facts entities.NutritionFacts, k float64) entities.NutritionFacts {
+	round := func(v float64) float64 {
+		return math.Round(v*k*10) / 10
+	}
+	return entities.NutritionFacts{
+		Calories: math.Round(facts.Calories * k),
+		Protein:  round(facts.Protein),
+		Fat:      round(facts.Fat),
+		Carbs:    round(facts.Carbs),
+	}
+}
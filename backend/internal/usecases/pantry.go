@@ -0,0 +1,144 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Homyakadze14/RecipeSite/internal/common"
+	"github.com/Homyakadze14/RecipeSite/internal/entities"
+)
+
+const defaultMatchLimit = 20
+
+var (
+	ErrPantryItemNotFound = errors.New("pantry item not found")
+	ErrNothingAtHand      = errors.New("ingredients must be provided or the pantry must not be empty")
+)
+
+type pantryStorage interface {
+	GetAll(ctx context.Context, userID int) ([]entities.PantryItem, error)
+	Add(ctx context.Context, userID int, names []string) (added int, err error)
+	Delete(ctx context.Context, userID, id int) error
+}
+
+type recipeStorageForPantry interface {
+	GetByIngredients(ctx context.Context, have []string, params *entities.IngredientSearch) ([]entities.RecipeMatch, error)
+}
+
+type userUseCaseForPantry interface {
+	GetByLogin(ctx context.Context, login string) (*entities.User, error)
+}
+
+type scaleUseCaseForPantry interface {
+	ConvertRecipe(recipe *entities.RecipeWithAuthor, system string)
+}
+
+type PantryUseCase struct {
+	storage       pantryStorage
+	recipeStorage recipeStorageForPantry
+	userUseCase   userUseCaseForPantry
+	scaleUseCase  scaleUseCaseForPantry
+}
+
+func NewPantryUseCase(st pantryStorage, rst recipeStorageForPantry, uu userUseCaseForPantry,
+	scu scaleUseCaseForPantry) *PantryUseCase {
+	return &PantryUseCase{
+		storage:       st,
+		recipeStorage: rst,
+		userUseCase:   uu,
+		scaleUseCase:  scu,
+	}
+}
+
+// getOwner gets the user if the pantry is the owner's, pantries are private.
+func (u *PantryUseCase) getOwner(ctx context.Context, login string, ownerID int) (*entities.User, error) {
+	user, err := u.userUseCase.GetByLogin(ctx, login)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("PantryUseCase - getOwner - u.userUseCase.GetByLogin: %w", err)
+	}
+
+	if !common.HavePermisson(ownerID, user.ID) {
+		return nil, common.ErrNoPermissions
+	}
+	return user, nil
+}
+
+func (u *PantryUseCase) GetAll(ctx context.Context, login string, ownerID int) ([]entities.PantryItem, error) {
+	user, err := u.getOwner(ctx, login, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("PantryUseCase - GetAll - u.getOwner: %w", err)
+	}
+
+	items, err := u.storage.GetAll(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("PantryUseCase - GetAll - u.storage.GetAll: %w", err)
+	}
+	return items, nil
+}
+
+func (u *PantryUseCase) Add(ctx context.Context, login string, ownerID int, params *entities.AddPantryItems) (int, error) {
+	user, err := u.getOwner(ctx, login, ownerID)
+	if err != nil {
+		return 0, fmt.Errorf("PantryUseCase - Add - u.getOwner: %w", err)
+	}
+
+	added, err := u.storage.Add(ctx, user.ID, params.Names)
+	if err != nil {
+		return 0, fmt.Errorf("PantryUseCase - Add - u.storage.Add: %w", err)
+	}
+	return added, nil
+}
+
+func (u *PantryUseCase) Delete(ctx context.Context, login string, ownerID, id int) error {
+	user, err := u.getOwner(ctx, login, ownerID)
+	if err != nil {
+		return fmt.Errorf("PantryUseCase - Delete - u.getOwner: %w", err)
+	}
+
+	err = u.storage.Delete(ctx, user.ID, id)
+	if err != nil {
+		if errors.Is(err, ErrPantryItemNotFound) {
+			return ErrPantryItemNotFound
+		}
+		return fmt.Errorf("PantryUseCase - Delete - u.storage.Delete: %w", err)
+	}
+	return nil
+}
+
+// FindRecipes ranks recipes by how many of their ingredients are in the
+// pantry of the viewer or among the given ones.
+func (u *PantryUseCase) FindRecipes(ctx context.Context, params *entities.IngredientSearch) (*entities.RecipeMatchList, error) {
+	have := params.Ingredients
+	if params.ViewerID != 0 && !params.IgnorePantry {
+		items, err := u.storage.GetAll(ctx, params.ViewerID)
+		if err != nil {
+			return nil, fmt.Errorf("PantryUseCase - FindRecipes - u.storage.GetAll: %w", err)
+		}
+		for _, item := range items {
+			have = append(have, item.Name)
+		}
+	}
+	if len(have) == 0 {
+		return nil, ErrNothingAtHand
+	}
+
+	if params.Limit == 0 {
+		params.Limit = defaultMatchLimit
+	}
+
+	matches, err := u.recipeStorage.GetByIngredients(ctx, have, params)
+	if err != nil {
+		return nil, fmt.Errorf("PantryUseCase - FindRecipes - u.recipeStorage.GetByIngredients: %w", err)
+	}
+
+	if params.Units != "" {
+		for i := range matches {
+			u.scaleUseCase.ConvertRecipe(matches[i].Recipe, params.Units)
+		}
+	}
+	return &entities.RecipeMatchList{Recipes: matches}, nil
+}
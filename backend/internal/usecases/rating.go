@@ -0,0 +1,135 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Homyakadze14/RecipeSite/internal/common"
+	"github.com/Homyakadze14/RecipeSite/internal/entities"
+)
+
+var (
+	ErrRatingNotFound = errors.New("rating not found")
+	ErrRateOwnRecipe  = errors.New("you can't rate your own recipe")
+)
+
+type ratingStorage interface {
+	Get(ctx context.Context, userID, recipeID int) (*entities.Rating, error)
+	GetAll(ctx context.Context, recipeID int, page *entities.RatingPage) ([]entities.Rating, error)
+	Summary(ctx context.Context, recipeID int) (*entities.RatingSummary, error)
+	Rate(ctx context.Context, rating *entities.Rating) (id int, err error)
+	Delete(ctx context.Context, userID, recipeID int) error
+}
+
+type recipeStorageForRating interface {
+	Get(ctx context.Context, id int) (*entities.Recipe, error)
+}
+
+type RatingUseCase struct {
+	storage       ratingStorage
+	recipeStorage recipeStorageForRating
+}
+
+func NewRatingUseCase(st ratingStorage, rst recipeStorageForRating) *RatingUseCase {
+	return &RatingUseCase{
+		storage:       st,
+		recipeStorage: rst,
+	}
+}
+
+// getRecipe gets the recipe if the user may see it.
+func (u *RatingUseCase) getRecipe(ctx context.Context, recipeID, userID int) (*entities.Recipe, error) {
+	recipe, err := u.recipeStorage.Get(ctx, recipeID)
+	if err != nil {
+		if errors.Is(err, ErrRecipeNotFound) {
+			return nil, ErrRecipeNotFound
+		}
+		return nil, fmt.Errorf("RatingUseCase - getRecipe - u.recipeStorage.Get: %w", err)
+	}
+
+	if !recipe.IsVisibleTo(userID) {
+		return nil, ErrRecipeNotFound
+	}
+	return recipe, nil
+}
+
+func (u *RatingUseCase) Summary(ctx context.Context, recipeID int) (*entities.RatingSummary, error) {
+	summary, err := u.storage.Summary(ctx, recipeID)
+	if err != nil {
+		return nil, fmt.Errorf("RatingUseCase - Summary - u.storage.Summary: %w", err)
+	}
+	return summary, nil
+}
+
+// UserRating gets the stars the user gave to the recipe, zero if the user
+// hasn't rated it.
+func (u *RatingUseCase) UserRating(ctx context.Context, userID, recipeID int) (int, error) {
+	rating, err := u.storage.Get(ctx, userID, recipeID)
+	if err != nil {
+		if errors.Is(err, ErrRatingNotFound) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("RatingUseCase - UserRating - u.storage.Get: %w", err)
+	}
+	return rating.Rating, nil
+}
+
+// GetAll gets a page of the ratings and reviews of the recipe the user may see.
+func (u *RatingUseCase) GetAll(ctx context.Context, recipeID, userID int, page *entities.RatingPage) (*entities.RatingList, error) {
+	_, err := u.getRecipe(ctx, recipeID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("RatingUseCase - GetAll - u.getRecipe: %w", err)
+	}
+
+	ratings, err := u.storage.GetAll(ctx, recipeID, page)
+	if err != nil {
+		if errors.Is(err, ErrBadCursor) {
+			return nil, ErrBadCursor
+		}
+		return nil, fmt.Errorf("RatingUseCase - GetAll - u.storage.GetAll: %w", err)
+	}
+
+	list := &entities.RatingList{Ratings: ratings}
+	if len(ratings) == page.Limit {
+		list.NextCursor = ratings[len(ratings)-1].Cursor
+	}
+	return list, nil
+}
+
+// Rate saves the rating of the user, a user has one rating per recipe which
+// is replaced when the recipe is rated again. Authors can't rate their own recipes.
+func (u *RatingUseCase) Rate(ctx context.Context, recipeID, userID int, params *entities.RateRecipe) (int, error) {
+	recipe, err := u.getRecipe(ctx, recipeID, userID)
+	if err != nil {
+		return -1, fmt.Errorf("RatingUseCase - Rate - u.getRecipe: %w", err)
+	}
+
+	if common.HavePermisson(recipe.UserID, userID) {
+		return -1, ErrRateOwnRecipe
+	}
+
+	rating := &entities.Rating{
+		UserID:   userID,
+		RecipeID: recipe.ID,
+		Rating:   params.Rating,
+		Review:   params.Review,
+	}
+
+	id, err := u.storage.Rate(ctx, rating)
+	if err != nil {
+		return -1, fmt.Errorf("RatingUseCase - Rate - u.storage.Rate: %w", err)
+	}
+	return id, nil
+}
+
+func (u *RatingUseCase) Delete(ctx context.Context, recipeID, userID int) error {
+	err := u.storage.Delete(ctx, userID, recipeID)
+	if err != nil {
+		if errors.Is(err, ErrRatingNotFound) {
+			return ErrRatingNotFound
+		}
+		return fmt.Errorf("RatingUseCase - Delete - u.storage.Delete: %w", err)
+	}
+	return nil
+}
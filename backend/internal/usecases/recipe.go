@@ -6,6 +6,9 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/Homyakadze14/RecipeSite/internal/common"
 	"github.com/Homyakadze14/RecipeSite/internal/entities"
@@ -16,19 +19,31 @@ var (
 	ErrEmptyPhotos         = errors.New("photos must be provided")
 	ErrComplexityMustBeInt = errors.New("complexitiy must be integer")
 	ErrBadOrderField       = errors.New("bad order field")
+	ErrEmptyIngredients    = errors.New("ingredients must be provided")
+	ErrEmptyInstructions   = errors.New("instructions or steps must be provided")
+	ErrStepPhotoIndex      = errors.New("step photo refers to a step which doesn't exist")
+	ErrBadCursor           = errors.New("bad cursor")
+	ErrEmptyCookingTime    = errors.New("need_time, prep_time or cook_time must be provided")
 )
 
 type recipeStorage interface {
-	GetAll(ctx context.Context) ([]entities.Recipe, error)
+	GetAll(ctx context.Context, page *entities.RecipePage) ([]entities.RecipeWithAuthor, error)
+	Count(ctx context.Context) (int, error)
 	GetFiltered(ctx context.Context, filter *entities.RecipeFilter) ([]entities.Recipe, error)
 	Get(ctx context.Context, id int) (*entities.Recipe, error)
 	Save(ctx context.Context, recipe *entities.Recipe) (id int, err error)
-	Update(ctx context.Context, updatedRecipe *entities.Recipe) error
+	Update(ctx context.Context, updatedRecipe, previous *entities.Recipe) error
+	SetStatus(ctx context.Context, recipe *entities.Recipe) error
 	Delete(ctx context.Context, recipe *entities.Recipe) error
+	ForksCount(ctx context.Context, recipeID int) (int, error)
+	GetLineage(ctx context.Context, recipeID, viewerID int) ([]entities.RecipeAncestor, error)
+	UnusedPhotos(ctx context.Context, urls []string) ([]string, error)
+	CheckTags(ctx context.Context, tags []entities.Tag) error
 }
 
 type userUseCase interface {
 	GetAuthor(ctx context.Context, userID int) (*entities.Author, error)
+	GetAuthors(ctx context.Context, ids []int) (map[int]*entities.Author, error)
 	GetByLogin(ctx context.Context, login string) (*entities.User, error)
 }
 
@@ -37,8 +52,23 @@ type likeUseCase interface {
 	IsAlreadyLike(ctx context.Context, like *entities.Like) (bool, error)
 }
 
+type bookmarkUseCase interface {
+	IsBookmarked(ctx context.Context, bookmark *entities.Bookmark) (bool, error)
+}
+
+type ratingUseCase interface {
+	Summary(ctx context.Context, recipeID int) (*entities.RatingSummary, error)
+	UserRating(ctx context.Context, userID, recipeID int) (int, error)
+}
+
+type cookLogUseCase interface {
+	CookedCount(ctx context.Context, recipeID int) (int, error)
+	GetGallery(ctx context.Context, recipeID int) ([]entities.CookLog, error)
+}
+
 type subscribeUseCase interface {
 	SendToMsgBroker(ctx context.Context, message *entities.RecipeCreationMsg) error
+	SendForkToMsgBroker(ctx context.Context, message *entities.RecipeForkMsg) error
 }
 
 type commentUseCase interface {
@@ -50,6 +80,17 @@ type fileStorageForRecipe interface {
 	Remove(path string) error
 }
 
+type scaleUseCase interface {
+	Scale(ingredients []entities.Ingredient, from, to int) []entities.Ingredient
+	ConvertRecipe(recipe *entities.RecipeWithAuthor, system string)
+}
+
+type nutritionUseCase interface {
+	Get(ctx context.Context, recipe *entities.Recipe) (*entities.Nutrition, error)
+	Refresh(ctx context.Context, recipe *entities.Recipe) (*entities.Nutrition, error)
+	Delete(ctx context.Context, recipeID int) error
+}
+
 type cacheRecipeRepository interface {
 	Set(ctx context.Context, key string, value interface{}) error
 	Get(ctx context.Context, key string, dest interface{}) error
@@ -64,10 +105,16 @@ type RecipeUseCases struct {
 	fileStorage           fileStorageForRecipe
 	subscribeUseCase      subscribeUseCase
 	cacheRecipeRepository cacheRecipeRepository
+	scaleUseCase          scaleUseCase
+	nutritionUseCase      nutritionUseCase
+	bookmarkUseCase       bookmarkUseCase
+	ratingUseCase         ratingUseCase
+	cookLogUseCase        cookLogUseCase
 }
 
 func NewRecipeUsecase(st recipeStorage, us userUseCase, lu likeUseCase,
-	fs fileStorageForRecipe, cu commentUseCase, subu subscribeUseCase, chRep cacheRecipeRepository) *RecipeUseCases {
+	fs fileStorageForRecipe, cu commentUseCase, subu subscribeUseCase, chRep cacheRecipeRepository,
+	scu scaleUseCase, nu nutritionUseCase, bu bookmarkUseCase, ru ratingUseCase, clu cookLogUseCase) *RecipeUseCases {
 	return &RecipeUseCases{
 		storage:               st,
 		userUseCase:           us,
@@ -76,74 +123,93 @@ func NewRecipeUsecase(st recipeStorage, us userUseCase, lu likeUseCase,
 		commentUseCase:        cu,
 		subscribeUseCase:      subu,
 		cacheRecipeRepository: chRep,
+		scaleUseCase:          scu,
+		nutritionUseCase:      nu,
+		bookmarkUseCase:       bu,
+		ratingUseCase:         ru,
+		cookLogUseCase:        clu,
 	}
 }
 
-func (r *RecipeUseCases) GetAll(ctx context.Context) ([]entities.RecipeWithAuthor, error) {
-	recipes, err := r.storage.GetAll(ctx)
+func (r *RecipeUseCases) GetAll(ctx context.Context, page *entities.RecipePage) (*entities.RecipeList, error) {
+	recipes, err := r.storage.GetAll(ctx, page)
 	if err != nil {
+		if errors.Is(err, ErrBadCursor) {
+			return nil, ErrBadCursor
+		}
 		return nil, fmt.Errorf("RecipeUseCase - GetAll - r.storage.GetAll: %w", err)
 	}
 
-	rwa := make([]entities.RecipeWithAuthor, 0, 10)
-	for _, recipe := range recipes {
-		rc := entities.RecipeWithAuthor{
-			ID:           recipe.ID,
-			UserID:       recipe.UserID,
-			Title:        recipe.Title,
-			About:        recipe.About,
-			Complexitiy:  recipe.Complexitiy,
-			NeedTime:     recipe.NeedTime,
-			Ingridients:  recipe.Ingridients,
-			Instructions: recipe.Instructions,
-			PhotosUrls:   recipe.PhotosUrls,
-			CreatedAt:    recipe.CreatedAt,
-			UpdatedAt:    recipe.UpdatedAt,
-		}
-
-		rc.Author, err = r.GetRecipeAuthor(ctx, recipe.UserID)
-		if err != nil {
-			return nil, fmt.Errorf("RecipeUseCase - GetAll - r.getRecipeAuthor: %w", err)
-		}
+	list := &entities.RecipeList{Recipes: recipes}
+	if len(recipes) == page.Limit {
+		list.NextCursor = recipes[len(recipes)-1].Cursor
+	}
 
-		rwa = append(rwa, rc)
+	list.Total, err = r.storage.Count(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("RecipeUseCase - GetAll - r.storage.Count: %w", err)
 	}
-	return rwa, nil
+
+	r.convertList(list.Recipes, page.Units)
+	return list, nil
 }
 
-func (r *RecipeUseCases) GetFiltered(ctx context.Context, filter *entities.RecipeFilter) ([]entities.RecipeWithAuthor, error) {
+func (r *RecipeUseCases) GetFiltered(ctx context.Context, filter *entities.RecipeFilter) (*entities.RecipeList, error) {
 	recipes, err := r.storage.GetFiltered(ctx, filter)
 	if err != nil {
 		if errors.Is(err, ErrBadOrderField) {
 			return nil, ErrBadOrderField
 		}
+		if errors.Is(err, ErrBadCursor) {
+			return nil, ErrBadCursor
+		}
 		return nil, fmt.Errorf("RecipeUseCase - GetFiltered - r.storage.GetFiltered: %w", err)
 	}
 
-	rwa := make([]entities.RecipeWithAuthor, 0, 10)
+	list, err := r.toRecipeList(ctx, recipes, filter.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("RecipeUseCase - GetFiltered - r.toRecipeList: %w", err)
+	}
+
+	r.convertList(list.Recipes, filter.Units)
+	return list, nil
+}
+
+// convertList moves the recipes of a list to the given measurement system,
+// an empty system leaves them as they were written.
+func (r *RecipeUseCases) convertList(recipes []entities.RecipeWithAuthor, system string) {
+	if system == "" {
+		return
+	}
+	for i := range recipes {
+		r.scaleUseCase.ConvertRecipe(&recipes[i], system)
+	}
+}
+
+// toRecipeList adds authors to the recipes. A full page means there may be
+// more recipes, so the list gets the cursor of its last recipe.
+func (r *RecipeUseCases) toRecipeList(ctx context.Context, recipes []entities.Recipe, limit int) (*entities.RecipeList, error) {
+	ids := make([]int, 0, len(recipes))
 	for _, recipe := range recipes {
-		rc := entities.RecipeWithAuthor{
-			ID:           recipe.ID,
-			UserID:       recipe.UserID,
-			Title:        recipe.Title,
-			About:        recipe.About,
-			Complexitiy:  recipe.Complexitiy,
-			NeedTime:     recipe.NeedTime,
-			Ingridients:  recipe.Ingridients,
-			Instructions: recipe.Instructions,
-			PhotosUrls:   recipe.PhotosUrls,
-			CreatedAt:    recipe.CreatedAt,
-			UpdatedAt:    recipe.UpdatedAt,
-		}
+		ids = append(ids, recipe.UserID)
+	}
 
-		rc.Author, err = r.GetRecipeAuthor(ctx, recipe.UserID)
-		if err != nil {
-			return nil, fmt.Errorf("RecipeUseCase - GetAll - r.getRecipeAuthor: %w", err)
-		}
+	authors, err := r.userUseCase.GetAuthors(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("RecipeUseCase - toRecipeList - r.userUseCase.GetAuthors: %w", err)
+	}
 
-		rwa = append(rwa, rc)
+	list := &entities.RecipeList{Recipes: make([]entities.RecipeWithAuthor, 0, len(recipes))}
+	for _, recipe := range recipes {
+		rc := recipe.ToRecipeWithAuthor()
+		rc.Author = authors[recipe.UserID]
+		list.Recipes = append(list.Recipes, *rc)
 	}
-	return rwa, nil
+
+	if limit != 0 && len(recipes) == limit {
+		list.NextCursor = recipes[len(recipes)-1].Cursor
+	}
+	return list, nil
 }
 
 func (r *RecipeUseCases) getRecipeFromCache(ctx context.Context, key string) (*entities.Recipe, error) {
@@ -180,43 +246,57 @@ func (r *RecipeUseCases) GetRecipeAuthor(ctx context.Context, userID int) (*enti
 	return author, nil
 }
 
-func (r *RecipeUseCases) formCacheKey(recipeID int) string {
+// recipeCacheKey is the key the recipe is cached under. Every use case that
+// changes a recipe drops this key.
+func recipeCacheKey(recipeID int) string {
 	return fmt.Sprintf("recipe:%v", recipeID)
 }
 
-func (r *RecipeUseCases) Get(ctx context.Context, id, userID int, authorized bool) (*entities.FullRecipe, error) {
-	chacheKey := r.formCacheKey(id)
+// GetRecipe gets the recipe if the user may see it.
+func (r *RecipeUseCases) GetRecipe(ctx context.Context, id, userID int) (*entities.Recipe, error) {
+	chacheKey := recipeCacheKey(id)
 	recipe, err := r.getRecipeFromCache(ctx, chacheKey)
 
 	if err != nil {
 		if errors.Is(err, common.ErrCacheKeyNotFound) {
 			recipe, err = r.getRecipeFromStorage(ctx, id)
 			if err != nil {
-				return nil, fmt.Errorf("RecipeUseCase - Get - r.getRecipeFromStorage: %w", err)
+				return nil, fmt.Errorf("RecipeUseCase - GetRecipe - r.getRecipeFromStorage: %w", err)
 			}
 
 			err = r.cacheRecipeRepository.Set(ctx, chacheKey, recipe)
 			if err != nil {
-				return nil, fmt.Errorf("RecipeUseCase - Get - r.cacheRecipeRepository.Set: %w", err)
+				return nil, fmt.Errorf("RecipeUseCase - GetRecipe - r.cacheRecipeRepository.Set: %w", err)
 			}
 		} else {
-			return nil, fmt.Errorf("RecipeUseCase - Get - r.getRecipeFromCache: %w", err)
+			return nil, fmt.Errorf("RecipeUseCase - GetRecipe - r.getRecipeFromCache: %w", err)
 		}
 	}
 
+	if !recipe.IsVisibleTo(userID) {
+		return nil, ErrRecipeNotFound
+	}
+	return recipe, nil
+}
+
+func (r *RecipeUseCases) Get(ctx context.Context, id, userID int, authorized bool,
+	params *entities.RecipeViewParams) (*entities.FullRecipe, error) {
+	recipe, err := r.GetRecipe(ctx, id, userID)
+	if err != nil {
+		return nil, fmt.Errorf("RecipeUseCase - Get - r.GetRecipe: %w", err)
+	}
+
 	fullRecipe := entities.FullRecipe{}
-	fullRecipe.Recipe = &entities.RecipeWithAuthor{
-		ID:           recipe.ID,
-		UserID:       recipe.UserID,
-		Title:        recipe.Title,
-		About:        recipe.About,
-		Complexitiy:  recipe.Complexitiy,
-		NeedTime:     recipe.NeedTime,
-		Ingridients:  recipe.Ingridients,
-		Instructions: recipe.Instructions,
-		PhotosUrls:   recipe.PhotosUrls,
-		CreatedAt:    recipe.CreatedAt,
-		UpdatedAt:    recipe.UpdatedAt,
+	fullRecipe.Recipe = recipe.ToRecipeWithAuthor()
+
+	if params.Servings != 0 && params.Servings != recipe.Servings {
+		fullRecipe.Recipe.Ingredients = r.scaleUseCase.Scale(recipe.Ingredients, recipe.Servings, params.Servings)
+		fullRecipe.Recipe.Ingridients = entities.FormatIngredients(fullRecipe.Recipe.Ingredients)
+		fullRecipe.Recipe.Servings = params.Servings
+	}
+
+	if params.Units != "" {
+		r.scaleUseCase.ConvertRecipe(fullRecipe.Recipe, params.Units)
 	}
 
 	fullRecipe.Recipe.Author, err = r.GetRecipeAuthor(ctx, fullRecipe.Recipe.UserID)
@@ -224,6 +304,14 @@ func (r *RecipeUseCases) Get(ctx context.Context, id, userID int, authorized boo
 		return nil, fmt.Errorf("RecipeUseCase - Get - r.getRecipeAuthor: %w", err)
 	}
 
+	fullRecipe.Nutrition, err = r.nutritionUseCase.Get(ctx, recipe)
+	if err != nil {
+		return nil, fmt.Errorf("RecipeUseCase - Get - r.nutritionUseCase.Get: %w", err)
+	}
+	if fullRecipe.Recipe.Servings != recipe.Servings {
+		fullRecipe.Nutrition.Total = scaleFacts(fullRecipe.Nutrition.PerServing, float64(fullRecipe.Recipe.Servings))
+	}
+
 	fullRecipe.Comments, err = r.commentUseCase.GetAll(ctx, recipe.ID)
 	if err != nil {
 		return nil, fmt.Errorf("RecipeUseCase - Get - r.commentUseCase.GetAll: %w", err)
@@ -234,6 +322,33 @@ func (r *RecipeUseCases) Get(ctx context.Context, id, userID int, authorized boo
 		return nil, fmt.Errorf("RecipeUseCase - Get - r.likeUseCase.LikesCount: %w", err)
 	}
 
+	fullRecipe.Rating, err = r.ratingUseCase.Summary(ctx, recipe.ID)
+	if err != nil {
+		return nil, fmt.Errorf("RecipeUseCase - Get - r.ratingUseCase.Summary: %w", err)
+	}
+
+	fullRecipe.ForksCount, err = r.storage.ForksCount(ctx, recipe.ID)
+	if err != nil {
+		return nil, fmt.Errorf("RecipeUseCase - Get - r.storage.ForksCount: %w", err)
+	}
+
+	fullRecipe.CookedCount, err = r.cookLogUseCase.CookedCount(ctx, recipe.ID)
+	if err != nil {
+		return nil, fmt.Errorf("RecipeUseCase - Get - r.cookLogUseCase.CookedCount: %w", err)
+	}
+
+	fullRecipe.CookPhotos, err = r.cookLogUseCase.GetGallery(ctx, recipe.ID)
+	if err != nil {
+		return nil, fmt.Errorf("RecipeUseCase - Get - r.cookLogUseCase.GetGallery: %w", err)
+	}
+
+	if recipe.ForkedFrom != nil {
+		fullRecipe.Lineage, err = r.storage.GetLineage(ctx, recipe.ID, userID)
+		if err != nil {
+			return nil, fmt.Errorf("RecipeUseCase - Get - r.storage.GetLineage: %w", err)
+		}
+	}
+
 	if authorized {
 		like := &entities.Like{
 			UserID:   userID,
@@ -243,6 +358,20 @@ func (r *RecipeUseCases) Get(ctx context.Context, id, userID int, authorized boo
 		if err != nil {
 			return nil, fmt.Errorf("RecipeUseCase - Get - r.likeUseCase.IsAlreadyLike: %w", err)
 		}
+
+		bookmark := &entities.Bookmark{
+			UserID:   userID,
+			RecipeID: fullRecipe.Recipe.ID,
+		}
+		fullRecipe.IsBookmarked, err = r.bookmarkUseCase.IsBookmarked(ctx, bookmark)
+		if err != nil {
+			return nil, fmt.Errorf("RecipeUseCase - Get - r.bookmarkUseCase.IsBookmarked: %w", err)
+		}
+
+		fullRecipe.UserRating, err = r.ratingUseCase.UserRating(ctx, userID, fullRecipe.Recipe.ID)
+		if err != nil {
+			return nil, fmt.Errorf("RecipeUseCase - Get - r.ratingUseCase.UserRating: %w", err)
+		}
 	}
 
 	return &fullRecipe, nil
@@ -259,6 +388,37 @@ func (r *RecipeUseCases) getUser(ctx context.Context, login string) (*entities.U
 	return user, nil
 }
 
+func (r *RecipeUseCases) checkStepPhotos(steps []entities.RecipeStep, photos map[int]io.ReadSeeker) error {
+	for idx := range photos {
+		if idx < 0 || idx >= len(steps) {
+			return ErrStepPhotoIndex
+		}
+	}
+	return nil
+}
+
+// saveStepPhotos uploads step photos and sets their urls on the matching steps.
+func (r *RecipeUseCases) saveStepPhotos(steps []entities.RecipeStep, photos map[int]io.ReadSeeker) error {
+	uploaded := make([]string, 0, len(photos))
+	for idx, photo := range photos {
+		url, err := r.fileStorage.Save([]io.ReadSeeker{photo}, "image/jpeg")
+		if err != nil {
+			saveErr := fmt.Errorf("RecipeUseCase - saveStepPhotos - r.fileStorage.Save: %w", err)
+
+			err := r.fileStorage.Remove(strings.Join(uploaded, ";"))
+			if err != nil {
+				return fmt.Errorf("%w; RecipeUseCase - saveStepPhotos - r.fileStorage.Remove: %w", saveErr, err)
+			}
+
+			return saveErr
+		}
+
+		steps[idx].PhotoURL = strings.TrimSuffix(url, ";")
+		uploaded = append(uploaded, steps[idx].PhotoURL)
+	}
+	return nil
+}
+
 func (r *RecipeUseCases) Create(ctx context.Context, login string, ownerID int, params *entities.CreateRecipe) error {
 	user, err := r.getUser(ctx, login)
 	if err != nil {
@@ -276,16 +436,58 @@ func (r *RecipeUseCases) Create(ctx context.Context, login string, ownerID int,
 	recipe := params.ToRecipe()
 	recipe.UserID = user.ID
 
+	firstPublish, err := recipe.ChangeStatus(params.StatusChange(), time.Now())
+	if err != nil {
+		return err
+	}
+
+	if len(recipe.Ingredients) == 0 {
+		return ErrEmptyIngredients
+	}
+
+	if len(recipe.Steps) == 0 {
+		return ErrEmptyInstructions
+	}
+
+	if recipe.TotalMinutes == 0 {
+		return ErrEmptyCookingTime
+	}
+
+	err = r.checkStepPhotos(recipe.Steps, params.StepPhotos)
+	if err != nil {
+		return err
+	}
+
+	err = r.storage.CheckTags(ctx, recipe.Tags)
+	if err != nil {
+		if errors.Is(err, ErrTagNotFound) {
+			return ErrTagNotFound
+		}
+		return fmt.Errorf("RecipeUseCase - Create - r.storage.CheckTags: %w", err)
+	}
+
 	recipe.PhotosUrls, err = r.fileStorage.Save(params.Photos, "image/jpeg")
 	if err != nil {
 		return fmt.Errorf("RecipeUseCase - Create - r.fileStorage.Save: %w", err)
 	}
 
+	err = r.saveStepPhotos(recipe.Steps, params.StepPhotos)
+	if err != nil {
+		stepsErr := fmt.Errorf("RecipeUseCase - Create - r.saveStepPhotos: %w", err)
+
+		err := r.fileStorage.Remove(recipe.PhotosUrls)
+		if err != nil {
+			return fmt.Errorf("%w; RecipeUseCase - Create - r.fileStorage.Remove: %w", stepsErr, err)
+		}
+
+		return stepsErr
+	}
+
 	id, err := r.storage.Save(ctx, recipe)
 	if err != nil {
 		storageErr := fmt.Errorf("RecipeUseCase - Create - r.storage.Save: %w", err)
 
-		err := r.fileStorage.Remove(recipe.PhotosUrls)
+		err := r.fileStorage.Remove(recipe.PhotosUrls + strings.Join(recipe.StepPhotosUrls(), ";"))
 		if err != nil {
 			return fmt.Errorf("%w; RecipeUseCase - Create - r.fileStorage.Remove: %w", storageErr, err)
 		}
@@ -293,17 +495,68 @@ func (r *RecipeUseCases) Create(ctx context.Context, login string, ownerID int,
 		return storageErr
 	}
 
+	if firstPublish {
+		recipe.ID = id
+		r.notifySubscribers(ctx, recipe)
+	}
+
+	return nil
+}
+
+// notifySubscribers tells the subscribers of the author about the new recipe.
+func (r *RecipeUseCases) notifySubscribers(ctx context.Context, recipe *entities.Recipe) {
 	message := &entities.RecipeCreationMsg{
-		CreatorID: user.ID,
-		RecipeID:  id,
+		CreatorID: recipe.UserID,
+		RecipeID:  recipe.ID,
 	}
 
 	go func() {
-		err = r.subscribeUseCase.SendToMsgBroker(ctx, message)
+		err := r.subscribeUseCase.SendToMsgBroker(ctx, message)
 		if err != nil {
-			slog.Error(fmt.Sprintf("RecipeUseCase - Create - r.subscribeUseCase.SendToMsgBroker: %s", err.Error()))
+			slog.Error(fmt.Sprintf("RecipeUseCase - notifySubscribers - r.subscribeUseCase.SendToMsgBroker: %s", err.Error()))
 		}
 	}()
+}
+
+// SetStatus publishes, schedules, archives or returns the recipe to drafts.
+// Subscribers are notified only the first time the recipe is published.
+func (r *RecipeUseCases) SetStatus(ctx context.Context, login string, ownerID, id int, change *entities.RecipeStatusChange) error {
+	user, err := r.getUser(ctx, login)
+	if err != nil {
+		return fmt.Errorf("RecipeUseCase - SetStatus - r.getUser: %w", err)
+	}
+
+	if !common.HavePermisson(ownerID, user.ID) {
+		return common.ErrNoPermissions
+	}
+
+	recipe, err := r.getRecipeFromStorage(ctx, id)
+	if err != nil {
+		return fmt.Errorf("RecipeUseCase - SetStatus - r.getRecipeFromStorage: %w", err)
+	}
+
+	if !common.HavePermisson(recipe.UserID, user.ID) {
+		return common.ErrNoPermissions
+	}
+
+	firstPublish, err := recipe.ChangeStatus(change, time.Now())
+	if err != nil {
+		return err
+	}
+
+	err = r.storage.SetStatus(ctx, recipe)
+	if err != nil {
+		return fmt.Errorf("RecipeUseCase - SetStatus - r.storage.SetStatus: %w", err)
+	}
+
+	_, err = r.cacheRecipeRepository.Del(ctx, recipeCacheKey(id))
+	if err != nil {
+		return fmt.Errorf("RecipeUseCase - SetStatus - r.cacheRecipeRepository.Del: %w", err)
+	}
+
+	if firstPublish {
+		r.notifySubscribers(ctx, recipe)
+	}
 
 	return nil
 }
@@ -318,7 +571,7 @@ func (r *RecipeUseCases) Update(ctx context.Context, login string, ownerID, id i
 		return common.ErrNoPermissions
 	}
 
-	chacheKey := r.formCacheKey(id)
+	chacheKey := recipeCacheKey(id)
 	recipe, err := r.getRecipeFromCache(ctx, chacheKey)
 	if err != nil {
 		if errors.Is(err, common.ErrCacheKeyNotFound) {
@@ -330,8 +583,23 @@ func (r *RecipeUseCases) Update(ctx context.Context, login string, ownerID, id i
 			return fmt.Errorf("RecipeUseCase - Update - r.getRecipeFromCache: %w", err)
 		}
 	}
+	previous := recipe.Clone()
+	oldStepPhotos := recipe.StepPhotosUrls()
 	params.UpdateValues(recipe)
 
+	err = r.checkStepPhotos(recipe.Steps, params.StepPhotos)
+	if err != nil {
+		return err
+	}
+
+	err = r.storage.CheckTags(ctx, recipe.Tags)
+	if err != nil {
+		if errors.Is(err, ErrTagNotFound) {
+			return ErrTagNotFound
+		}
+		return fmt.Errorf("RecipeUseCase - Update - r.storage.CheckTags: %w", err)
+	}
+
 	oldPhotos := ""
 	if params.HavePhotos() {
 		oldPhotos = recipe.PhotosUrls
@@ -341,11 +609,35 @@ func (r *RecipeUseCases) Update(ctx context.Context, login string, ownerID, id i
 		}
 	}
 
-	err = r.storage.Update(ctx, recipe)
+	err = r.saveStepPhotos(recipe.Steps, params.StepPhotos)
+	if err != nil {
+		stepsErr := fmt.Errorf("RecipeUseCase - Update - r.saveStepPhotos: %w", err)
+
+		if params.HavePhotos() {
+			err := r.fileStorage.Remove(recipe.PhotosUrls)
+			if err != nil {
+				return fmt.Errorf("%w; RecipeUseCase - Update - r.fileStorage.Remove: %w", stepsErr, err)
+			}
+		}
+
+		return stepsErr
+	}
+
+	err = r.storage.Update(ctx, recipe, previous)
 	if err != nil {
 		storageErr := fmt.Errorf("RecipeUseCase - Update - r.storage.Update: %w", err)
 
-		err := r.fileStorage.Remove(recipe.PhotosUrls)
+		// Only the photos uploaded with this request are removed, the
+		// recipe still points at its old ones.
+		newPhotos := make([]string, 0, len(params.StepPhotos)+1)
+		if params.HavePhotos() {
+			newPhotos = append(newPhotos, strings.TrimSuffix(recipe.PhotosUrls, ";"))
+		}
+		for idx := range params.StepPhotos {
+			newPhotos = append(newPhotos, recipe.Steps[idx].PhotoURL)
+		}
+
+		err := r.fileStorage.Remove(strings.Join(newPhotos, ";"))
 		if err != nil {
 			return fmt.Errorf("%w; RecipeUseCase - Update - r.fileStorage.Remove: %w", storageErr, err)
 		}
@@ -358,16 +650,50 @@ func (r *RecipeUseCases) Update(ctx context.Context, login string, ownerID, id i
 		return fmt.Errorf("RecipeUseCase - Update - r.cacheRecipeRepository.Del: %w", err)
 	}
 
-	if oldPhotos != "" {
-		err = r.fileStorage.Remove(oldPhotos)
+	if len(params.Ingredients) != 0 || params.Ingridients != "" || params.Servings != 0 {
+		_, err = r.nutritionUseCase.Refresh(ctx, recipe)
 		if err != nil {
-			return fmt.Errorf("RecipeUseCase - Update - r.fileStorage.Remove: %w", err)
+			return fmt.Errorf("RecipeUseCase - Update - r.nutritionUseCase.Refresh: %w", err)
+		}
+	}
+
+	replacedPhotos := strings.Split(oldPhotos, ";")
+	for _, url := range oldStepPhotos {
+		if !slices.Contains(recipe.StepPhotosUrls(), url) {
+			replacedPhotos = append(replacedPhotos, url)
 		}
 	}
+	err = r.removeUnusedPhotos(ctx, replacedPhotos)
+	if err != nil {
+		return fmt.Errorf("RecipeUseCase - Update - r.removeUnusedPhotos: %w", err)
+	}
 
 	return nil
 }
 
+// removeUnusedPhotos removes the photos from the file storage unless some
+// recipe still uses them, forks share photos with the original recipe.
+func (r *RecipeUseCases) removeUnusedPhotos(ctx context.Context, urls []string) error {
+	urls = slices.DeleteFunc(urls, func(url string) bool { return url == "" })
+	if len(urls) == 0 {
+		return nil
+	}
+
+	unused, err := r.storage.UnusedPhotos(ctx, urls)
+	if err != nil {
+		return fmt.Errorf("RecipeUseCase - removeUnusedPhotos - r.storage.UnusedPhotos: %w", err)
+	}
+	if len(unused) == 0 {
+		return nil
+	}
+
+	err = r.fileStorage.Remove(strings.Join(unused, ";"))
+	if err != nil {
+		return fmt.Errorf("RecipeUseCase - removeUnusedPhotos - r.fileStorage.Remove: %w", err)
+	}
+	return nil
+}
+
 func (r *RecipeUseCases) Delete(ctx context.Context, login string, ownerID int, id int) error {
 	user, err := r.getUser(ctx, login)
 	if err != nil {
@@ -378,7 +704,7 @@ func (r *RecipeUseCases) Delete(ctx context.Context, login string, ownerID int,
 		return common.ErrNoPermissions
 	}
 
-	chacheKey := r.formCacheKey(id)
+	chacheKey := recipeCacheKey(id)
 	recipe, err := r.getRecipeFromCache(ctx, chacheKey)
 	if err != nil {
 		if errors.Is(err, common.ErrCacheKeyNotFound) {
@@ -401,5 +727,10 @@ func (r *RecipeUseCases) Delete(ctx context.Context, login string, ownerID int,
 		return fmt.Errorf("RecipeUseCase - Delete - r.cacheRecipeRepository.Del: %w", err)
 	}
 
+	err = r.nutritionUseCase.Delete(ctx, id)
+	if err != nil {
+		return fmt.Errorf("RecipeUseCase - Delete - r.nutritionUseCase.Delete: %w", err)
+	}
+
 	return nil
 }
@@ -0,0 +1,122 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Homyakadze14/RecipeSite/internal/common"
+	"github.com/Homyakadze14/RecipeSite/internal/entities"
+)
+
+var ErrRevisionNotFound = errors.New("revision not found")
+
+type revisionStorage interface {
+	GetRevisions(ctx context.Context, recipeID int) ([]entities.RecipeRevision, error)
+	GetRevision(ctx context.Context, recipeID, version int) (*entities.RecipeRevision, error)
+}
+
+type recipeUseCaseForRevision interface {
+	GetRecipe(ctx context.Context, id, userID int) (*entities.Recipe, error)
+	Update(ctx context.Context, login string, ownerID, id int, params *entities.UpdateRecipe) error
+}
+
+type RevisionUseCase struct {
+	storage       revisionStorage
+	recipeUseCase recipeUseCaseForRevision
+}
+
+func NewRevisionUseCase(st revisionStorage, ru recipeUseCaseForRevision) *RevisionUseCase {
+	return &RevisionUseCase{
+		storage:       st,
+		recipeUseCase: ru,
+	}
+}
+
+func (u *RevisionUseCase) getRecipe(ctx context.Context, recipeID, userID int) (*entities.Recipe, error) {
+	recipe, err := u.recipeUseCase.GetRecipe(ctx, recipeID, userID)
+	if err != nil {
+		if errors.Is(err, ErrRecipeNotFound) {
+			return nil, ErrRecipeNotFound
+		}
+		return nil, fmt.Errorf("RevisionUseCase - getRecipe - u.recipeUseCase.GetRecipe: %w", err)
+	}
+	return recipe, nil
+}
+
+func (u *RevisionUseCase) getRevision(ctx context.Context, recipeID, version int) (*entities.RecipeRevision, error) {
+	revision, err := u.storage.GetRevision(ctx, recipeID, version)
+	if err != nil {
+		if errors.Is(err, ErrRevisionNotFound) {
+			return nil, ErrRevisionNotFound
+		}
+		return nil, fmt.Errorf("RevisionUseCase - getRevision - u.storage.GetRevision: %w", err)
+	}
+	return revision, nil
+}
+
+// GetAll lists the revisions of the recipe the user may see.
+func (u *RevisionUseCase) GetAll(ctx context.Context, recipeID, userID int) ([]entities.RecipeRevision, error) {
+	_, err := u.getRecipe(ctx, recipeID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("RevisionUseCase - GetAll - u.getRecipe: %w", err)
+	}
+
+	revisions, err := u.storage.GetRevisions(ctx, recipeID)
+	if err != nil {
+		return nil, fmt.Errorf("RevisionUseCase - GetAll - u.storage.GetRevisions: %w", err)
+	}
+	return revisions, nil
+}
+
+// Diff compares two revisions of the recipe, or a revision with the current recipe.
+func (u *RevisionUseCase) Diff(ctx context.Context, recipeID, userID int, params *entities.RevisionDiffParams) (*entities.RecipeDiff, error) {
+	recipe, err := u.getRecipe(ctx, recipeID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("RevisionUseCase - Diff - u.getRecipe: %w", err)
+	}
+
+	from, err := u.getRevision(ctx, recipeID, params.From)
+	if err != nil {
+		return nil, fmt.Errorf("RevisionUseCase - Diff - u.getRevision: %w", err)
+	}
+
+	to := recipe
+	if params.To != 0 {
+		revision, err := u.getRevision(ctx, recipeID, params.To)
+		if err != nil {
+			return nil, fmt.Errorf("RevisionUseCase - Diff - u.getRevision: %w", err)
+		}
+		to = revision.Snapshot
+	}
+
+	return &entities.RecipeDiff{
+		From:    params.From,
+		To:      params.To,
+		Changes: entities.Diff(from.Snapshot, to),
+	}, nil
+}
+
+// Restore brings the recipe back to the revision. It is an ordinary update,
+// so the current version becomes a revision as well.
+func (u *RevisionUseCase) Restore(ctx context.Context, login string, ownerID, recipeID, version int) error {
+	recipe, err := u.getRecipe(ctx, recipeID, ownerID)
+	if err != nil {
+		return fmt.Errorf("RevisionUseCase - Restore - u.getRecipe: %w", err)
+	}
+
+	if !common.HavePermisson(recipe.UserID, ownerID) {
+		return common.ErrNoPermissions
+	}
+
+	revision, err := u.getRevision(ctx, recipeID, version)
+	if err != nil {
+		return fmt.Errorf("RevisionUseCase - Restore - u.getRevision: %w", err)
+	}
+
+	err = u.recipeUseCase.Update(ctx, login, ownerID, recipeID, revision.Snapshot.ToUpdateRecipe())
+	if err != nil {
+		return fmt.Errorf("RevisionUseCase - Restore - u.recipeUseCase.Update: %w", err)
+	}
+	return nil
+}
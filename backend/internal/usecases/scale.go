@@ -0,0 +1,72 @@
+package usecases
+
+import (
+	"github.com/Homyakadze14/RecipeSite/internal/entities"
+	"github.com/Homyakadze14/RecipeSite/internal/units"
+)
+
+type ScaleUseCase struct{}
+
+func NewScaleUseCase() *ScaleUseCase {
+	return &ScaleUseCase{}
+}
+
+// Scale recalculates ingredient quantities from the base servings of a recipe
+// to the wanted ones. The passed slice is not modified.
+func (u *ScaleUseCase) Scale(ingredients []entities.Ingredient, from, to int) []entities.Ingredient {
+	scaled := make([]entities.Ingredient, 0, len(ingredients))
+	for _, ingredient := range ingredients {
+		if ingredient.Quantity != 0 && from > 0 {
+			ingredient.Quantity = ingredient.Quantity * float64(to) / float64(from)
+			ingredient.Quantity, ingredient.Unit = units.Normalize(ingredient.Quantity, ingredient.Unit)
+			ingredient.Quantity, ingredient.DisplayQuantity = units.Round(ingredient.Quantity, ingredient.Unit)
+		}
+		scaled = append(scaled, ingredient)
+	}
+	return scaled
+}
+
+// Convert moves ingredient quantities to the given measurement system.
+// The passed slice is not modified.
+func (u *ScaleUseCase) Convert(ingredients []entities.Ingredient, system string) []entities.Ingredient {
+	converted := make([]entities.Ingredient, 0, len(ingredients))
+	for _, ingredient := range ingredients {
+		if ingredient.Quantity != 0 {
+			ingredient.Quantity, ingredient.Unit = units.Convert(ingredient.Quantity, ingredient.Unit,
+				ingredient.Name, units.System(system))
+			ingredient.Quantity, ingredient.DisplayQuantity = units.Round(ingredient.Quantity, ingredient.Unit)
+		}
+		converted = append(converted, ingredient)
+	}
+	return converted
+}
+
+// ConvertSteps rewrites temperatures in the steps to the given measurement system.
+// The passed slice is not modified.
+func (u *ScaleUseCase) ConvertSteps(steps []entities.RecipeStep, system string) []entities.RecipeStep {
+	converted := make([]entities.RecipeStep, 0, len(steps))
+	for _, step := range steps {
+		step.Text = units.ConvertTemperatures(step.Text, units.System(system))
+		converted = append(converted, step)
+	}
+	return converted
+}
+
+// ConvertRecipe moves the ingredients and the steps of the recipe to the given
+// measurement system. Recipes in lists carry the legacy text only, so the text
+// is converted when there are no structured ingredients or steps.
+func (u *ScaleUseCase) ConvertRecipe(recipe *entities.RecipeWithAuthor, system string) {
+	if len(recipe.Ingredients) != 0 {
+		recipe.Ingredients = u.Convert(recipe.Ingredients, system)
+		recipe.Ingridients = entities.FormatIngredients(recipe.Ingredients)
+	} else if recipe.Ingridients != "" {
+		recipe.Ingridients = entities.FormatIngredients(u.Convert(entities.ParseIngredients(recipe.Ingridients), system))
+	}
+
+	if len(recipe.Steps) != 0 {
+		recipe.Steps = u.ConvertSteps(recipe.Steps, system)
+		recipe.Instructions = entities.FormatSteps(recipe.Steps)
+	} else {
+		recipe.Instructions = units.ConvertTemperatures(recipe.Instructions, units.System(system))
+	}
+}
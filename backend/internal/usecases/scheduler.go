@@ -0,0 +1,98 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Homyakadze14/RecipeSite/internal/entities"
+)
+
+// publishBatchSize is the count of recipes published in one query.
+const publishBatchSize = 100
+
+type publicationStorage interface {
+	PublishDue(ctx context.Context, now time.Time, limit int) ([]entities.Publication, error)
+}
+
+type cacheRepositoryForScheduler interface {
+	Del(ctx context.Context, key string) (res int64, err error)
+}
+
+type subscribeUseCaseForScheduler interface {
+	SendToMsgBroker(ctx context.Context, message *entities.RecipeCreationMsg) error
+}
+
+// PublishScheduler publishes scheduled recipes once their publish_at comes.
+type PublishScheduler struct {
+	storage          publicationStorage
+	cacheRepository  cacheRepositoryForScheduler
+	subscribeUseCase subscribeUseCaseForScheduler
+	interval         time.Duration
+}
+
+func NewPublishScheduler(st publicationStorage, chRep cacheRepositoryForScheduler,
+	subu subscribeUseCaseForScheduler, interval time.Duration) *PublishScheduler {
+	return &PublishScheduler{
+		storage:          st,
+		cacheRepository:  chRep,
+		subscribeUseCase: subu,
+		interval:         interval,
+	}
+}
+
+// Run publishes due recipes every interval until the context is done.
+func (s *PublishScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		err := s.PublishDue(ctx)
+		if err != nil {
+			slog.Error(fmt.Sprintf("PublishScheduler - Run - s.PublishDue: %s", err.Error()))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PublishDue publishes all the recipes whose time has come.
+func (s *PublishScheduler) PublishDue(ctx context.Context) error {
+	for {
+		publications, err := s.storage.PublishDue(ctx, time.Now(), publishBatchSize)
+		if err != nil {
+			return fmt.Errorf("PublishScheduler - PublishDue - s.storage.PublishDue: %w", err)
+		}
+
+		for _, publication := range publications {
+			// The recipe is already published, so the rest of the batch
+			// must be handled even if its cache entry is left behind.
+			_, err = s.cacheRepository.Del(ctx, recipeCacheKey(publication.RecipeID))
+			if err != nil {
+				slog.Error(fmt.Sprintf("PublishScheduler - PublishDue - s.cacheRepository.Del: %s", err.Error()))
+			}
+
+			if !publication.First {
+				continue
+			}
+
+			message := &entities.RecipeCreationMsg{
+				CreatorID: publication.CreatorID,
+				RecipeID:  publication.RecipeID,
+			}
+			err = s.subscribeUseCase.SendToMsgBroker(ctx, message)
+			if err != nil {
+				slog.Error(fmt.Sprintf("PublishScheduler - PublishDue - s.subscribeUseCase.SendToMsgBroker: %s", err.Error()))
+			}
+		}
+
+		if len(publications) < publishBatchSize {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,257 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Homyakadze14/RecipeSite/internal/common"
+	"github.com/Homyakadze14/RecipeSite/internal/entities"
+)
+
+var ErrShoppingListItemNotFound = errors.New("shopping list item not found")
+
+type shoppingListStorage interface {
+	Get(ctx context.Context, userID int) (*entities.ShoppingList, error)
+	GetItem(ctx context.Context, userID, id int) (*entities.ShoppingListItem, error)
+	Add(ctx context.Context, userID int, ingredients []entities.Ingredient) error
+	UpdateItem(ctx context.Context, userID int, item *entities.ShoppingListItem) error
+	DeleteItem(ctx context.Context, userID, id int) error
+	Clear(ctx context.Context, userID int, onlyChecked bool) (removed int, err error)
+}
+
+type userUseCaseForShoppingList interface {
+	GetByLogin(ctx context.Context, login string) (*entities.User, error)
+}
+
+type recipeUseCaseForShoppingList interface {
+	GetRecipe(ctx context.Context, id, userID int) (*entities.Recipe, error)
+}
+
+type mealPlanUseCaseForShoppingList interface {
+	Get(ctx context.Context, login string, ownerID int, params *entities.MealPlanRange) (*entities.MealPlan, error)
+}
+
+type scaleUseCaseForShoppingList interface {
+	Scale(ingredients []entities.Ingredient, from, to int) []entities.Ingredient
+}
+
+type ShoppingListUseCase struct {
+	storage         shoppingListStorage
+	userUseCase     userUseCaseForShoppingList
+	recipeUseCase   recipeUseCaseForShoppingList
+	mealPlanUseCase mealPlanUseCaseForShoppingList
+	scaleUseCase    scaleUseCaseForShoppingList
+}
+
+func NewShoppingListUseCase(st shoppingListStorage, uu userUseCaseForShoppingList, ru recipeUseCaseForShoppingList,
+	mu mealPlanUseCaseForShoppingList, su scaleUseCaseForShoppingList) *ShoppingListUseCase {
+	return &ShoppingListUseCase{
+		storage:         st,
+		userUseCase:     uu,
+		recipeUseCase:   ru,
+		mealPlanUseCase: mu,
+		scaleUseCase:    su,
+	}
+}
+
+// getOwner gets the user if the shopping list is the owner's, lists are private.
+func (u *ShoppingListUseCase) getOwner(ctx context.Context, login string, ownerID int) (*entities.User, error) {
+	user, err := u.userUseCase.GetByLogin(ctx, login)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("ShoppingListUseCase - getOwner - u.userUseCase.GetByLogin: %w", err)
+	}
+
+	if !common.HavePermisson(ownerID, user.ID) {
+		return nil, common.ErrNoPermissions
+	}
+	return user, nil
+}
+
+func (u *ShoppingListUseCase) Get(ctx context.Context, login string, ownerID int) (*entities.ShoppingList, error) {
+	user, err := u.getOwner(ctx, login, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("ShoppingListUseCase - Get - u.getOwner: %w", err)
+	}
+
+	list, err := u.storage.Get(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("ShoppingListUseCase - Get - u.storage.Get: %w", err)
+	}
+
+	list.Round()
+	return list, nil
+}
+
+// add merges the ingredients into the list of the user and gets the saved list.
+func (u *ShoppingListUseCase) add(ctx context.Context, userID int, ingredients []entities.Ingredient) (*entities.ShoppingList, error) {
+	err := u.storage.Add(ctx, userID, ingredients)
+	if err != nil {
+		return nil, fmt.Errorf("ShoppingListUseCase - add - u.storage.Add: %w", err)
+	}
+
+	list, err := u.storage.Get(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("ShoppingListUseCase - add - u.storage.Get: %w", err)
+	}
+
+	list.Round()
+	return list, nil
+}
+
+// recipeIngredients gets the ingredients of the recipe for the servings.
+// Without servings the servings of the recipe are used.
+func (u *ShoppingListUseCase) recipeIngredients(ctx context.Context, userID, recipeID, servings int) ([]entities.Ingredient, error) {
+	recipe, err := u.recipeUseCase.GetRecipe(ctx, recipeID, userID)
+	if err != nil {
+		if errors.Is(err, ErrRecipeNotFound) {
+			return nil, ErrRecipeNotFound
+		}
+		return nil, fmt.Errorf("ShoppingListUseCase - recipeIngredients - u.recipeUseCase.GetRecipe: %w", err)
+	}
+
+	if servings == 0 {
+		return recipe.Ingredients, nil
+	}
+	return u.scaleUseCase.Scale(recipe.Ingredients, recipe.Servings, servings), nil
+}
+
+// AddRecipes puts the ingredients of the recipes on the list, identical
+// ingredients are summed up.
+func (u *ShoppingListUseCase) AddRecipes(ctx context.Context, login string, ownerID int,
+	params *entities.AddShoppingListRecipes) (*entities.ShoppingList, error) {
+	user, err := u.getOwner(ctx, login, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("ShoppingListUseCase - AddRecipes - u.getOwner: %w", err)
+	}
+
+	var ingredients []entities.Ingredient
+	for _, recipe := range params.Recipes {
+		recipeIngredients, err := u.recipeIngredients(ctx, user.ID, recipe.RecipeID, recipe.Servings)
+		if err != nil {
+			return nil, fmt.Errorf("ShoppingListUseCase - AddRecipes - u.recipeIngredients: %w", err)
+		}
+		ingredients = append(ingredients, recipeIngredients...)
+	}
+
+	list, err := u.add(ctx, user.ID, ingredients)
+	if err != nil {
+		return nil, fmt.Errorf("ShoppingListUseCase - AddRecipes - u.add: %w", err)
+	}
+	return list, nil
+}
+
+// AddMealPlan puts the ingredients of the meals planned for the days on the
+// list, scaled to the planned servings.
+func (u *ShoppingListUseCase) AddMealPlan(ctx context.Context, login string, ownerID int,
+	params *entities.MealPlanRange) (*entities.ShoppingList, error) {
+	// The meal plan use case checks the plan is the owner's.
+	plan, err := u.mealPlanUseCase.Get(ctx, login, ownerID, params)
+	if err != nil {
+		if errors.Is(err, ErrMealPlanRange) {
+			return nil, ErrMealPlanRange
+		}
+		return nil, fmt.Errorf("ShoppingListUseCase - AddMealPlan - u.mealPlanUseCase.Get: %w", err)
+	}
+
+	var ingredients []entities.Ingredient
+	for _, day := range plan.Days {
+		for _, meals := range [][]entities.MealPlanEntry{day.Breakfast, day.Lunch, day.Dinner} {
+			for _, meal := range meals {
+				mealIngredients, err := u.recipeIngredients(ctx, ownerID, meal.Recipe.ID, meal.Servings)
+				if err != nil {
+					return nil, fmt.Errorf("ShoppingListUseCase - AddMealPlan - u.recipeIngredients: %w", err)
+				}
+				ingredients = append(ingredients, mealIngredients...)
+			}
+		}
+	}
+
+	list, err := u.add(ctx, ownerID, ingredients)
+	if err != nil {
+		return nil, fmt.Errorf("ShoppingListUseCase - AddMealPlan - u.add: %w", err)
+	}
+	return list, nil
+}
+
+// AddItem puts an item which isn't from a recipe on the list.
+func (u *ShoppingListUseCase) AddItem(ctx context.Context, login string, ownerID int,
+	params *entities.AddShoppingListItem) (*entities.ShoppingList, error) {
+	user, err := u.getOwner(ctx, login, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("ShoppingListUseCase - AddItem - u.getOwner: %w", err)
+	}
+
+	list, err := u.add(ctx, user.ID, []entities.Ingredient{params.ToIngredient()})
+	if err != nil {
+		return nil, fmt.Errorf("ShoppingListUseCase - AddItem - u.add: %w", err)
+	}
+	return list, nil
+}
+
+// UpdateItem checks the item off or changes it.
+func (u *ShoppingListUseCase) UpdateItem(ctx context.Context, login string, ownerID, id int,
+	params *entities.UpdateShoppingListItem) error {
+	user, err := u.getOwner(ctx, login, ownerID)
+	if err != nil {
+		return fmt.Errorf("ShoppingListUseCase - UpdateItem - u.getOwner: %w", err)
+	}
+
+	item, err := u.storage.GetItem(ctx, user.ID, id)
+	if err != nil {
+		if errors.Is(err, ErrShoppingListItemNotFound) {
+			return ErrShoppingListItemNotFound
+		}
+		return fmt.Errorf("ShoppingListUseCase - UpdateItem - u.storage.GetItem: %w", err)
+	}
+
+	params.UpdateValues(item)
+
+	err = u.storage.UpdateItem(ctx, user.ID, item)
+	if err != nil {
+		return fmt.Errorf("ShoppingListUseCase - UpdateItem - u.storage.UpdateItem: %w", err)
+	}
+	return nil
+}
+
+func (u *ShoppingListUseCase) DeleteItem(ctx context.Context, login string, ownerID, id int) error {
+	user, err := u.getOwner(ctx, login, ownerID)
+	if err != nil {
+		return fmt.Errorf("ShoppingListUseCase - DeleteItem - u.getOwner: %w", err)
+	}
+
+	err = u.storage.DeleteItem(ctx, user.ID, id)
+	if err != nil {
+		if errors.Is(err, ErrShoppingListItemNotFound) {
+			return ErrShoppingListItemNotFound
+		}
+		return fmt.Errorf("ShoppingListUseCase - DeleteItem - u.storage.DeleteItem: %w", err)
+	}
+	return nil
+}
+
+// Clear empties the list or removes only the items bought already.
+func (u *ShoppingListUseCase) Clear(ctx context.Context, login string, ownerID int, params *entities.ClearShoppingList) (int, error) {
+	user, err := u.getOwner(ctx, login, ownerID)
+	if err != nil {
+		return 0, fmt.Errorf("ShoppingListUseCase - Clear - u.getOwner: %w", err)
+	}
+
+	removed, err := u.storage.Clear(ctx, user.ID, params.Checked)
+	if err != nil {
+		return 0, fmt.Errorf("ShoppingListUseCase - Clear - u.storage.Clear: %w", err)
+	}
+	return removed, nil
+}
+
+// Export renders the list as plain text or Markdown.
+func (u *ShoppingListUseCase) Export(ctx context.Context, login string, ownerID int, params *entities.ShoppingListExport) (string, error) {
+	list, err := u.Get(ctx, login, ownerID)
+	if err != nil {
+		return "", fmt.Errorf("ShoppingListUseCase - Export - u.Get: %w", err)
+	}
+	return list.Export(params.Format), nil
+}
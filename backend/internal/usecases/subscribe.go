@@ -24,6 +24,7 @@ type subscribeStorage interface {
 
 type msgBrokerRepository interface {
 	Send(ctx context.Context, message *entities.RecipeCreationMsg) error
+	SendFork(ctx context.Context, message *entities.RecipeForkMsg) error
 }
 
 type userUseCaseForSubscribe interface {
@@ -126,3 +127,12 @@ func (u *SubscribeUseCases) SendToMsgBroker(ctx context.Context, message *entiti
 
 	return nil
 }
+
+func (u *SubscribeUseCases) SendForkToMsgBroker(ctx context.Context, message *entities.RecipeForkMsg) error {
+	err := u.msgBrokerRepository.SendFork(ctx, message)
+	if err != nil {
+		return fmt.Errorf("SubscribeUseCases - SendForkToMsgBroker - u.msgBrokerRepository.SendFork: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Homyakadze14/RecipeSite/internal/entities"
+)
+
+var (
+	ErrTagNotFound = errors.New("tag not found")
+)
+
+type tagStorage interface {
+	GetAll(ctx context.Context) ([]entities.TagWithCount, error)
+	GetBySlug(ctx context.Context, slug string) (*entities.Tag, error)
+}
+
+type recipeUseCaseForTag interface {
+	GetFiltered(ctx context.Context, filter *entities.RecipeFilter) (*entities.RecipeList, error)
+}
+
+type TagUseCase struct {
+	storage       tagStorage
+	recipeUseCase recipeUseCaseForTag
+}
+
+func NewTagUseCase(st tagStorage, ru recipeUseCaseForTag) *TagUseCase {
+	return &TagUseCase{
+		storage:       st,
+		recipeUseCase: ru,
+	}
+}
+
+func (u *TagUseCase) GetAll(ctx context.Context) ([]entities.TagWithCount, error) {
+	tags, err := u.storage.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("TagUseCase - GetAll - u.storage.GetAll: %w", err)
+	}
+	return tags, nil
+}
+
+// GetRecipes gets a page of the newest recipes with the tag.
+func (u *TagUseCase) GetRecipes(ctx context.Context, slug string, page *entities.RecipePage) (*entities.RecipeList, error) {
+	_, err := u.storage.GetBySlug(ctx, slug)
+	if err != nil {
+		if errors.Is(err, ErrTagNotFound) {
+			return nil, ErrTagNotFound
+		}
+		return nil, fmt.Errorf("TagUseCase - GetRecipes - u.storage.GetBySlug: %w", err)
+	}
+
+	filter := &entities.RecipeFilter{
+		Tags:       []string{slug},
+		Cursor:     page.Cursor,
+		Limit:      page.Limit,
+		Units:      page.Units,
+		OrderField: "updated_at",
+		OrderBy:    1,
+	}
+	recipes, err := u.recipeUseCase.GetFiltered(ctx, filter)
+	if err != nil {
+		if errors.Is(err, ErrBadCursor) {
+			return nil, ErrBadCursor
+		}
+		return nil, fmt.Errorf("TagUseCase - GetRecipes - u.recipeUseCase.GetFiltered: %w", err)
+	}
+	return recipes, nil
+}
@@ -0,0 +1,190 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Homyakadze14/RecipeSite/internal/common"
+	"github.com/Homyakadze14/RecipeSite/internal/entities"
+)
+
+// purgeBatchSize is the count of recipes purged in one transaction.
+const purgeBatchSize = 100
+
+type trashStorage interface {
+	GetRecipes(ctx context.Context, userID int) ([]entities.Recipe, error)
+	GetComments(ctx context.Context, userID int) ([]entities.TrashComment, error)
+	RestoreRecipe(ctx context.Context, id, userID int, deletedAfter time.Time) error
+	RestoreComment(ctx context.Context, id, userID int, deletedAfter time.Time) error
+	Purge(ctx context.Context, deletedBefore time.Time, limit int) (files []string, recipes int, err error)
+}
+
+type userUseCaseForTrash interface {
+	GetByLogin(ctx context.Context, login string) (*entities.User, error)
+	Restore(ctx context.Context, params *entities.UserLogin, deletedAfter time.Time) (*entities.AuthUser, error)
+}
+
+type fileStorageForTrash interface {
+	Remove(path string) error
+}
+
+type cacheRepositoryForTrash interface {
+	Del(ctx context.Context, key string) (res int64, err error)
+}
+
+// TrashUseCase keeps deleted recipes, comments and users restorable for the
+// retention time and purges them afterwards.
+type TrashUseCase struct {
+	storage         trashStorage
+	userUseCase     userUseCaseForTrash
+	fileStorage     fileStorageForTrash
+	cacheRepository cacheRepositoryForTrash
+	retention       time.Duration
+	interval        time.Duration
+}
+
+func NewTrashUseCase(st trashStorage, us userUseCaseForTrash, fs fileStorageForTrash,
+	chRep cacheRepositoryForTrash, retention, interval time.Duration) *TrashUseCase {
+	return &TrashUseCase{
+		storage:         st,
+		userUseCase:     us,
+		fileStorage:     fs,
+		cacheRepository: chRep,
+		retention:       retention,
+		interval:        interval,
+	}
+}
+
+func (u *TrashUseCase) getOwner(ctx context.Context, login string, ownerID int) (*entities.User, error) {
+	user, err := u.userUseCase.GetByLogin(ctx, login)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("TrashUseCase - getOwner - u.userUseCase.GetByLogin: %w", err)
+	}
+
+	if !common.HavePermisson(ownerID, user.ID) {
+		return nil, common.ErrNoPermissions
+	}
+	return user, nil
+}
+
+// deletedAfter is the oldest deletion time which may still be restored.
+func (u *TrashUseCase) deletedAfter() time.Time {
+	return time.Now().Add(-u.retention)
+}
+
+func (u *TrashUseCase) Get(ctx context.Context, login string, ownerID int) (*entities.Trash, error) {
+	user, err := u.getOwner(ctx, login, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("TrashUseCase - Get - u.getOwner: %w", err)
+	}
+
+	trash := &entities.Trash{PurgeAfter: u.retention.String()}
+	trash.Recipes, err = u.storage.GetRecipes(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("TrashUseCase - Get - u.storage.GetRecipes: %w", err)
+	}
+
+	trash.Comments, err = u.storage.GetComments(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("TrashUseCase - Get - u.storage.GetComments: %w", err)
+	}
+
+	return trash, nil
+}
+
+func (u *TrashUseCase) RestoreRecipe(ctx context.Context, login string, ownerID, id int) error {
+	user, err := u.getOwner(ctx, login, ownerID)
+	if err != nil {
+		return fmt.Errorf("TrashUseCase - RestoreRecipe - u.getOwner: %w", err)
+	}
+
+	err = u.storage.RestoreRecipe(ctx, id, user.ID, u.deletedAfter())
+	if err != nil {
+		if errors.Is(err, ErrRecipeNotFound) {
+			return ErrRecipeNotFound
+		}
+		return fmt.Errorf("TrashUseCase - RestoreRecipe - u.storage.RestoreRecipe: %w", err)
+	}
+
+	_, err = u.cacheRepository.Del(ctx, recipeCacheKey(id))
+	if err != nil {
+		return fmt.Errorf("TrashUseCase - RestoreRecipe - u.cacheRepository.Del: %w", err)
+	}
+
+	return nil
+}
+
+func (u *TrashUseCase) RestoreComment(ctx context.Context, login string, ownerID, id int) error {
+	user, err := u.getOwner(ctx, login, ownerID)
+	if err != nil {
+		return fmt.Errorf("TrashUseCase - RestoreComment - u.getOwner: %w", err)
+	}
+
+	err = u.storage.RestoreComment(ctx, id, user.ID, u.deletedAfter())
+	if err != nil {
+		if errors.Is(err, ErrCommentNotFound) {
+			return ErrCommentNotFound
+		}
+		return fmt.Errorf("TrashUseCase - RestoreComment - u.storage.RestoreComment: %w", err)
+	}
+
+	return nil
+}
+
+// RestoreUser brings back a deleted account by its credentials.
+func (u *TrashUseCase) RestoreUser(ctx context.Context, params *entities.UserLogin) (*entities.AuthUser, error) {
+	auth, err := u.userUseCase.Restore(ctx, params, u.deletedAfter())
+	if err != nil {
+		return nil, fmt.Errorf("TrashUseCase - RestoreUser - u.userUseCase.Restore: %w", err)
+	}
+	return auth, nil
+}
+
+// Run purges the trash every interval until the context is done.
+func (u *TrashUseCase) Run(ctx context.Context) {
+	ticker := time.NewTicker(u.interval)
+	defer ticker.Stop()
+
+	for {
+		err := u.Purge(ctx)
+		if err != nil {
+			slog.Error(fmt.Sprintf("TrashUseCase - Run - u.Purge: %s", err.Error()))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Purge removes everything deleted longer than the retention time ago,
+// together with the photos in the file storage.
+func (u *TrashUseCase) Purge(ctx context.Context) error {
+	for {
+		files, recipes, err := u.storage.Purge(ctx, u.deletedAfter(), purgeBatchSize)
+		if err != nil {
+			return fmt.Errorf("TrashUseCase - Purge - u.storage.Purge: %w", err)
+		}
+
+		// The rows are gone already, a photo which failed to be removed
+		// mustn't stop the rest from being removed.
+		for _, file := range files {
+			err = u.fileStorage.Remove(file)
+			if err != nil {
+				slog.Error(fmt.Sprintf("TrashUseCase - Purge - u.fileStorage.Remove: %s", err.Error()))
+			}
+		}
+
+		if recipes < purgeBatchSize {
+			return nil
+		}
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"slices"
+	"time"
 
 	"github.com/Homyakadze14/RecipeSite/internal/common"
 	"github.com/Homyakadze14/RecipeSite/internal/entities"
@@ -19,10 +21,16 @@ type userStorage interface {
 	GetByEmail(ctx context.Context, email string) (*entities.User, error)
 	Update(ctx context.Context, user *entities.User) error
 	UpdatePassword(ctx context.Context, user *entities.User) error
-	GetRecipes(ctx context.Context, userID int) ([]entities.Recipe, error)
+	GetRecipes(ctx context.Context, userID int, hidden bool) ([]entities.Recipe, error)
 	GetAuthor(ctx context.Context, id int) (*entities.Author, error)
+	GetAuthors(ctx context.Context, ids []int) (map[int]*entities.Author, error)
 	GetIconByLogin(ctx context.Context, login string) (*entities.UserIcon, error)
 	IsAlreadySubscribe(ctx context.Context, info *entities.SubscribeInfo) (bool, error)
+	Delete(ctx context.Context, userID int) (recipeIDs []int, err error)
+	GetDeleted(ctx context.Context, login, email string) (*entities.User, error)
+	Restore(ctx context.Context, user *entities.User) error
+	GetPublicCollections(ctx context.Context, userID int) ([]entities.Collection, error)
+	GetCookHistory(ctx context.Context, userID int) ([]entities.CookLog, error)
 }
 
 type fileStorage interface {
@@ -49,6 +57,8 @@ type cache interface {
 	Set(ctx context.Context, key string, value interface{}) error
 	Get(ctx context.Context, key string, dest interface{}) error
 	Del(ctx context.Context, key string) (res int64, err error)
+	MGet(ctx context.Context, keys []string, dest []interface{}) (found []bool, err error)
+	MSet(ctx context.Context, values map[string]interface{}) error
 }
 
 type UserUseCase struct {
@@ -78,6 +88,7 @@ var (
 	ErrUserUnique        = errors.New("user with this credentials already exists")
 	ErrUserNotFound      = errors.New("user not found")
 	ErrUserWrongPassword = errors.New("wrong password")
+	ErrUserCredentials   = errors.New("login or email must be provided")
 )
 
 func (u *UserUseCase) GenerateJWT(userID int) (*entities.JWTToken, error) {
@@ -150,6 +161,58 @@ func (u *UserUseCase) GetAuthor(ctx context.Context, id int) (*entities.Author,
 	return author, nil
 }
 
+// GetAuthors gets the authors of the users with the given ids in a constant
+// count of queries. Users which don't exist are absent in the result.
+func (u *UserUseCase) GetAuthors(ctx context.Context, ids []int) (map[int]*entities.Author, error) {
+	ids = slices.Clone(ids)
+	slices.Sort(ids)
+	ids = slices.Compact(ids)
+
+	keys := make([]string, 0, len(ids))
+	dest := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, u.formCacheKey(id))
+		dest = append(dest, &entities.Author{})
+	}
+
+	found, err := u.cache.MGet(ctx, keys, dest)
+	if err != nil {
+		return nil, fmt.Errorf("UserUseCase - GetAuthors - u.cache.MGet: %w", err)
+	}
+
+	authors := make(map[int]*entities.Author, len(ids))
+	missed := make([]int, 0, len(ids))
+	for i, id := range ids {
+		if found[i] {
+			authors[id] = dest[i].(*entities.Author)
+		} else {
+			missed = append(missed, id)
+		}
+	}
+
+	if len(missed) == 0 {
+		return authors, nil
+	}
+
+	stored, err := u.storage.GetAuthors(ctx, missed)
+	if err != nil {
+		return nil, fmt.Errorf("UserUseCase - GetAuthors - u.storage.GetAuthors: %w", err)
+	}
+
+	toCache := make(map[string]interface{}, len(stored))
+	for id, author := range stored {
+		authors[id] = author
+		toCache[u.formCacheKey(id)] = author
+	}
+
+	err = u.cache.MSet(ctx, toCache)
+	if err != nil {
+		return nil, fmt.Errorf("UserUseCase - GetAuthors - u.cache.MSet: %w", err)
+	}
+
+	return authors, nil
+}
+
 func (u *UserUseCase) GetByLogin(ctx context.Context, login string) (*entities.User, error) {
 	user, err := u.storage.GetByLogin(ctx, login)
 	if err != nil {
@@ -235,6 +298,74 @@ func (u *UserUseCase) Signin(ctx context.Context, params *entities.UserLogin) (*
 	return auth, nil
 }
 
+// Delete moves the user with all the recipes and comments to the trash and signs the user out everywhere.
+// The sessions are removed by the storage together with the soft delete.
+func (u *UserUseCase) Delete(ctx context.Context, login string, ownerID int) error {
+	user, err := u.GetByLogin(ctx, login)
+	if err != nil {
+		return fmt.Errorf("UserUseCase - Delete - u.GetByLogin: %w", err)
+	}
+
+	if !common.HavePermisson(ownerID, user.ID) {
+		return common.ErrNoPermissions
+	}
+
+	recipeIDs, err := u.storage.Delete(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("UserUseCase - Delete - u.storage.Delete: %w", err)
+	}
+
+	for _, id := range recipeIDs {
+		_, err = u.cache.Del(ctx, recipeCacheKey(id))
+		if err != nil {
+			return fmt.Errorf("UserUseCase - Delete - u.cache.Del: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Restore brings back the user deleted after the time and signs the user in.
+func (u *UserUseCase) Restore(ctx context.Context, params *entities.UserLogin, deletedAfter time.Time) (*entities.AuthUser, error) {
+	if params.Login == "" && params.Email == "" {
+		return nil, ErrUserCredentials
+	}
+
+	user, err := u.storage.GetDeleted(ctx, params.Login, params.Email)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("UserUseCase - Restore - u.storage.GetDeleted: %w", err)
+	}
+
+	err = u.comparePasswords(user.Password, params.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.DeletedAt.After(deletedAfter) {
+		return nil, ErrUserNotFound
+	}
+
+	err = u.storage.Restore(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("UserUseCase - Restore - u.storage.Restore: %w", err)
+	}
+
+	sess, err := u.sessionManager.Create(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("UserUseCase - Restore - u.sessionManager.Create: %w", err)
+	}
+
+	auth := &entities.AuthUser{
+		Login:     user.Login,
+		SessionID: sess.ID,
+	}
+
+	return auth, nil
+}
+
 func (u *UserUseCase) Logout(ctx *gin.Context) error {
 	err := u.sessionManager.DestroySession(ctx)
 	if err != nil {
@@ -358,28 +489,20 @@ func (u *UserUseCase) Get(ctx context.Context, login string, ownerID int, author
 			if err != nil {
 				return nil, fmt.Errorf("UserUseCase - Get - u.likeUseCases.GetLikedRecipies: %w", err)
 			}
-			rwa := make([]entities.RecipeWithAuthor, 0, 10)
+			ids := make([]int, 0, len(likedRecipes))
 			for _, recipe := range likedRecipes {
-				rc := entities.RecipeWithAuthor{
-					ID:           recipe.ID,
-					UserID:       recipe.UserID,
-					Title:        recipe.Title,
-					About:        recipe.About,
-					Complexitiy:  recipe.Complexitiy,
-					NeedTime:     recipe.NeedTime,
-					Ingridients:  recipe.Ingridients,
-					Instructions: recipe.Instructions,
-					PhotosUrls:   recipe.PhotosUrls,
-					CreatedAt:    recipe.CreatedAt,
-					UpdatedAt:    recipe.UpdatedAt,
-				}
-
-				rc.Author, err = u.GetAuthor(ctx, recipe.UserID)
-				if err != nil {
-					return nil, fmt.Errorf("RecipeUseCase - GetAll - r.getRecipeAuthor: %w", err)
-				}
-
-				rwa = append(rwa, rc)
+				ids = append(ids, recipe.UserID)
+			}
+			authors, err := u.GetAuthors(ctx, ids)
+			if err != nil {
+				return nil, fmt.Errorf("UserUseCase - Get - u.GetAuthors: %w", err)
+			}
+
+			rwa := make([]entities.RecipeWithAuthor, 0, len(likedRecipes))
+			for _, recipe := range likedRecipes {
+				rc := recipe.ToRecipeWithAuthor()
+				rc.Author = authors[recipe.UserID]
+				rwa = append(rwa, *rc)
 			}
 			userInfo.LikedRecipies = rwa
 		}
@@ -395,34 +518,36 @@ func (u *UserUseCase) Get(ctx context.Context, login string, ownerID int, author
 		}
 	}
 
-	recipies, err := u.storage.GetRecipes(ctx, userInfo.ID)
+	// Drafts, scheduled and archived recipes are shown to the author only.
+	isOwner := authorized && common.HavePermisson(ownerID, user.ID)
+	recipies, err := u.storage.GetRecipes(ctx, userInfo.ID, isOwner)
 	if err != nil {
 		return nil, fmt.Errorf("UserUseCase - Get - u.storage.GetRecipes: %w", err)
 	}
-	rwa := make([]entities.RecipeWithAuthor, 0, 10)
+	// All the recipes are written by the user.
+	author := &entities.Author{
+		Login:   user.Login,
+		IconURL: user.IconURL,
+	}
+	rwa := make([]entities.RecipeWithAuthor, 0, len(recipies))
 	for _, recipe := range recipies {
-		rc := entities.RecipeWithAuthor{
-			ID:           recipe.ID,
-			UserID:       recipe.UserID,
-			Title:        recipe.Title,
-			About:        recipe.About,
-			Complexitiy:  recipe.Complexitiy,
-			NeedTime:     recipe.NeedTime,
-			Ingridients:  recipe.Ingridients,
-			Instructions: recipe.Instructions,
-			PhotosUrls:   recipe.PhotosUrls,
-			CreatedAt:    recipe.CreatedAt,
-			UpdatedAt:    recipe.UpdatedAt,
-		}
+		rc := recipe.ToRecipeWithAuthor()
+		rc.Author = author
+		rwa = append(rwa, *rc)
+	}
+	userInfo.Recipies = rwa
 
-		rc.Author, err = u.GetAuthor(ctx, recipe.UserID)
+	userInfo.Collections, err = u.storage.GetPublicCollections(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("UserUseCase - Get - u.storage.GetPublicCollections: %w", err)
+	}
+
+	if isOwner {
+		userInfo.CookHistory, err = u.storage.GetCookHistory(ctx, user.ID)
 		if err != nil {
-			return nil, fmt.Errorf("RecipeUseCase - GetAll - r.getRecipeAuthor: %w", err)
+			return nil, fmt.Errorf("UserUseCase - Get - u.storage.GetCookHistory: %w", err)
 		}
-
-		rwa = append(rwa, rc)
 	}
-	userInfo.Recipies = rwa
 
 	return userInfo, nil
 }
@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS ingredients(
+    id INT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    recipe_id INT references recipes(id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    quantity DOUBLE PRECISION NOT NULL DEFAULT 0,
+    unit VARCHAR(20) NOT NULL DEFAULT '',
+    note VARCHAR(250) NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS ingredients_recipe_id_idx ON ingredients(recipe_id);
+
+-- Best-effort split of the legacy free-text ingredients into rows.
+-- Understands "200 g flour" and "flour - 200 g" lines, everything else is kept as a plain name.
+INSERT INTO ingredients(recipe_id, position, name, quantity, unit)
+SELECT recipe_id,
+       ROW_NUMBER() OVER (PARTITION BY recipe_id ORDER BY line_no),
+       LEFT(COALESCE(lead_qty[3], trail_qty[1], line), 100),
+       COALESCE(REPLACE(COALESCE(lead_qty[1], trail_qty[2]), ',', '.')::DOUBLE PRECISION, 0),
+       LOWER(COALESCE(lead_qty[2], trail_qty[3], ''))
+FROM (
+    SELECT r.id AS recipe_id, t.line_no, BTRIM(t.line, E' \t\r-*•') AS line
+    FROM recipes r, regexp_split_to_table(r.ingridients, E'\n') WITH ORDINALITY AS t(line, line_no)
+) lines,
+LATERAL regexp_match(line, '^(\d+(?:[.,]\d+)?)\s*(kg|g|mg|ml|l|tsp|tbsp|cups?|oz|lb|pcs|кг|г|мг|мл|л|шт)?\.?\s+(.+)$', 'i') AS lead_qty,
+LATERAL regexp_match(line, '^(.+?)\s*[-–—:]\s*(\d+(?:[.,]\d+)?)\s*(kg|g|mg|ml|l|tsp|tbsp|cups?|oz|lb|pcs|кг|г|мг|мл|л|шт)?\.?$', 'i') AS trail_qty
+WHERE line <> '';
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS recipe_steps(
+    id INT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    recipe_id INT references recipes(id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    text VARCHAR(2000) NOT NULL,
+    duration INT NOT NULL DEFAULT 0,
+    photo_url TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS recipe_steps_recipe_id_idx ON recipe_steps(recipe_id);
+
+-- Every non-empty line of the legacy instructions becomes a step.
+INSERT INTO recipe_steps(recipe_id, position, text)
+SELECT recipe_id,
+       ROW_NUMBER() OVER (PARTITION BY recipe_id ORDER BY line_no),
+       LEFT(line, 2000)
+FROM (
+    SELECT r.id AS recipe_id, t.line_no, BTRIM(regexp_replace(t.line, '^\s*\d+[.)]\s*', ''), E' \t\r') AS line
+    FROM recipes r, regexp_split_to_table(r.instructions, E'\n') WITH ORDINALITY AS t(line, line_no)
+) lines
+WHERE line <> '';
@@ -0,0 +1 @@
+ALTER TABLE recipes ADD COLUMN IF NOT EXISTS servings INT NOT NULL DEFAULT 1;
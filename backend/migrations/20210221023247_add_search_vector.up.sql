@@ -0,0 +1,25 @@
+ALTER TABLE recipes ADD COLUMN IF NOT EXISTS search_vector tsvector;
+
+CREATE OR REPLACE FUNCTION recipes_search_vector_update() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector('russian', coalesce(NEW.title, '')), 'A') ||
+        setweight(to_tsvector('russian', coalesce(NEW.ingridients, '')), 'B') ||
+        setweight(to_tsvector('russian', coalesce(NEW.about, '')), 'C') ||
+        setweight(to_tsvector('russian', coalesce(NEW.instructions, '')), 'D');
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS recipes_search_vector_trigger ON recipes;
+CREATE TRIGGER recipes_search_vector_trigger
+    BEFORE INSERT OR UPDATE OF title, ingridients, about, instructions ON recipes
+    FOR EACH ROW EXECUTE FUNCTION recipes_search_vector_update();
+
+UPDATE recipes SET search_vector =
+    setweight(to_tsvector('russian', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('russian', coalesce(ingridients, '')), 'B') ||
+    setweight(to_tsvector('russian', coalesce(about, '')), 'C') ||
+    setweight(to_tsvector('russian', coalesce(instructions, '')), 'D');
+
+CREATE INDEX IF NOT EXISTS recipes_search_vector_idx ON recipes USING GIN(search_vector);
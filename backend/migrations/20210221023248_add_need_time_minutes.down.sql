@@ -0,0 +1 @@
+DROP FUNCTION IF EXISTS need_time_minutes(TEXT);
@@ -0,0 +1,35 @@
+-- need_time is free text like "1 h 30 min", "45 минут" or "1:30".
+-- need_time_minutes turns it into minutes, NULL when nothing can be parsed.
+CREATE OR REPLACE FUNCTION need_time_minutes(need_time TEXT) RETURNS INT AS $$
+DECLARE
+    t TEXT := lower(coalesce(need_time, ''));
+    m TEXT[];
+    hours NUMERIC := 0;
+    minutes NUMERIC := 0;
+BEGIN
+    m := regexp_match(t, '^\s*(\d+):(\d{1,2})\s*$');
+    IF m IS NOT NULL THEN
+        RETURN m[1]::INT * 60 + m[2]::INT;
+    END IF;
+
+    m := regexp_match(t, '(\d+(?:[.,]\d+)?)\s*(?:h|ч)');
+    IF m IS NOT NULL THEN
+        hours := replace(m[1], ',', '.')::NUMERIC;
+    END IF;
+
+    m := regexp_match(t, '(\d+)\s*(?:m|мин)');
+    IF m IS NOT NULL THEN
+        minutes := m[1]::NUMERIC;
+    END IF;
+
+    IF hours = 0 AND minutes = 0 THEN
+        m := regexp_match(t, '(\d+)');
+        IF m IS NULL THEN
+            RETURN NULL;
+        END IF;
+        minutes := m[1]::NUMERIC;
+    END IF;
+
+    RETURN round(hours * 60 + minutes)::INT;
+END
+$$ LANGUAGE plpgsql IMMUTABLE;
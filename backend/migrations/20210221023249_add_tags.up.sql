@@ -0,0 +1,38 @@
+CREATE TABLE IF NOT EXISTS tags(
+    id INT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    slug VARCHAR(50) NOT NULL UNIQUE,
+    name VARCHAR(50) NOT NULL,
+    kind VARCHAR(20) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS recipe_tags(
+    recipe_id INT references recipes(id) ON DELETE CASCADE,
+    tag_id INT references tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (recipe_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS recipe_tags_tag_id_idx ON recipe_tags(tag_id);
+
+INSERT INTO tags(slug, name, kind) VALUES
+    ('breakfast', 'Breakfast', 'meal'),
+    ('lunch', 'Lunch', 'meal'),
+    ('dinner', 'Dinner', 'meal'),
+    ('snack', 'Snack', 'meal'),
+    ('dessert', 'Dessert', 'meal'),
+    ('drink', 'Drink', 'meal'),
+    ('soup', 'Soup', 'category'),
+    ('salad', 'Salad', 'category'),
+    ('baking', 'Baking', 'category'),
+    ('main-course', 'Main course', 'category'),
+    ('side-dish', 'Side dish', 'category'),
+    ('sauce', 'Sauce', 'category'),
+    ('vegan', 'Vegan', 'category'),
+    ('russian', 'Russian', 'cuisine'),
+    ('italian', 'Italian', 'cuisine'),
+    ('french', 'French', 'cuisine'),
+    ('georgian', 'Georgian', 'cuisine'),
+    ('japanese', 'Japanese', 'cuisine'),
+    ('chinese', 'Chinese', 'cuisine'),
+    ('mexican', 'Mexican', 'cuisine'),
+    ('indian', 'Indian', 'cuisine')
+ON CONFLICT (slug) DO NOTHING;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS recipes_diets_idx;
+DROP INDEX IF EXISTS recipes_allergens_idx;
+ALTER TABLE recipes DROP COLUMN IF EXISTS diets;
+ALTER TABLE recipes DROP COLUMN IF EXISTS allergens;
@@ -0,0 +1,5 @@
+ALTER TABLE recipes ADD COLUMN IF NOT EXISTS allergens TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE recipes ADD COLUMN IF NOT EXISTS diets TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS recipes_allergens_idx ON recipes USING GIN(allergens);
+CREATE INDEX IF NOT EXISTS recipes_diets_idx ON recipes USING GIN(diets);
@@ -0,0 +1,58 @@
+-- Nutrition facts per 100 g. Keywords are beginnings of words in ingredient
+-- names, piece_weight is grams in one piece (0 when foods aren't counted in pieces).
+CREATE TABLE IF NOT EXISTS nutrition_foods(
+    id INT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    keywords TEXT[] NOT NULL,
+    calories DOUBLE PRECISION NOT NULL,
+    protein DOUBLE PRECISION NOT NULL,
+    fat DOUBLE PRECISION NOT NULL,
+    carbs DOUBLE PRECISION NOT NULL,
+    piece_weight DOUBLE PRECISION NOT NULL DEFAULT 0
+);
+
+INSERT INTO nutrition_foods(name, keywords, calories, protein, fat, carbs, piece_weight) VALUES
+    ('flour', '{flour,мук}', 364, 10.3, 1.0, 76.3, 0),
+    ('sugar', '{sugar,сахар}', 387, 0, 0, 100, 0),
+    ('butter', '{butter,сливочн}', 717, 0.9, 81, 0.1, 0),
+    ('vegetable oil', '{oil,olive,масл,подсолнечн,растительн,оливков}', 884, 0, 100, 0, 0),
+    ('egg', '{egg,яйц,яиц}', 143, 12.6, 9.5, 0.7, 50),
+    ('milk', '{milk,молок}', 60, 3.2, 3.2, 4.8, 0),
+    ('cream', '{cream,сливк}', 292, 2.2, 30, 3, 0),
+    ('sour cream', '{сметан}', 206, 2.8, 20, 3.2, 0),
+    ('cheese', '{cheese,сыр}', 350, 25, 27, 1.3, 0),
+    ('cottage cheese', '{cottage,творог}', 121, 17, 5, 1.8, 0),
+    ('yogurt', '{yogurt,йогурт}', 61, 3.5, 3.3, 4.7, 0),
+    ('rice', '{rice,рис}', 360, 6.7, 0.7, 79, 0),
+    ('buckwheat', '{buckwheat,гречк,гречн}', 343, 13, 3.4, 72, 0),
+    ('oats', '{oat,овсян}', 389, 16.9, 6.9, 66, 0),
+    ('pasta', '{pasta,spaghetti,noodle,макарон,спагетти,лапш}', 371, 13, 1.5, 75, 0),
+    ('bread', '{bread,хлеб}', 265, 9, 3.2, 49, 0),
+    ('potato', '{potato,картоф}', 77, 2, 0.1, 17, 150),
+    ('onion', '{onion,лук}', 40, 1.1, 0.1, 9.3, 100),
+    ('garlic', '{garlic,чеснок}', 149, 6.4, 0.5, 33, 5),
+    ('carrot', '{carrot,морков}', 41, 0.9, 0.2, 9.6, 80),
+    ('tomato', '{tomato,томат,помидор}', 18, 0.9, 0.2, 3.9, 120),
+    ('cucumber', '{cucumber,огур}', 15, 0.7, 0.1, 3.6, 120),
+    ('cabbage', '{cabbage,капуст}', 25, 1.3, 0.1, 5.8, 0),
+    ('mushroom', '{mushroom,гриб,шампиньон}', 22, 3.1, 0.3, 3.3, 20),
+    ('apple', '{apple,яблок}', 52, 0.3, 0.2, 14, 180),
+    ('banana', '{banana,банан}', 89, 1.1, 0.3, 23, 120),
+    ('lemon', '{lemon,лимон}', 29, 1.1, 0.3, 9, 100),
+    ('chicken', '{chicken,куриц,курин}', 190, 23, 10.5, 0, 0),
+    ('beef', '{beef,говядин}', 250, 26, 15, 0, 0),
+    ('pork', '{pork,свинин}', 242, 27, 14, 0, 0),
+    ('minced meat', '{mince,фарш}', 254, 17, 20, 0, 0),
+    ('salmon', '{salmon,лосос,семг}', 208, 20, 13, 0, 0),
+    ('cod', '{cod,треск}', 82, 18, 0.7, 0, 0),
+    ('shrimp', '{shrimp,prawn,кревет}', 99, 24, 0.3, 0.2, 0),
+    ('beans', '{bean,фасол}', 333, 21, 1.2, 60, 0),
+    ('lentils', '{lentil,чечевиц}', 352, 25, 1.1, 63, 0),
+    ('walnuts', '{walnut,орех}', 654, 15, 65, 14, 0),
+    ('honey', '{honey,мед,мёд}', 304, 0.3, 0, 82, 0),
+    ('cocoa', '{cocoa,какао}', 228, 19.6, 13.7, 58, 0),
+    ('chocolate', '{chocolate,шоколад}', 546, 4.9, 31, 61, 0),
+    ('starch', '{starch,крахмал}', 381, 0.3, 0.1, 91, 0),
+    ('salt', '{salt,соль}', 0, 0, 0, 0, 0),
+    ('water', '{water,вод}', 0, 0, 0, 0, 0)
+ON CONFLICT (name) DO NOTHING;
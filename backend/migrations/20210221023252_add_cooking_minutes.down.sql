@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS recipes_total_minutes_idx;
+
+ALTER TABLE recipes ADD COLUMN IF NOT EXISTS need_time VARCHAR(20) NOT NULL DEFAULT '';
+UPDATE recipes SET need_time = total_minutes || ' min';
+
+ALTER TABLE recipes DROP COLUMN IF EXISTS total_minutes;
+ALTER TABLE recipes DROP COLUMN IF EXISTS cook_minutes;
+ALTER TABLE recipes DROP COLUMN IF EXISTS prep_minutes;
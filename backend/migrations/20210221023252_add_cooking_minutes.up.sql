@@ -0,0 +1,13 @@
+ALTER TABLE recipes ADD COLUMN IF NOT EXISTS prep_minutes INT NOT NULL DEFAULT 0;
+ALTER TABLE recipes ADD COLUMN IF NOT EXISTS cook_minutes INT NOT NULL DEFAULT 0;
+ALTER TABLE recipes ADD COLUMN IF NOT EXISTS total_minutes INT NOT NULL DEFAULT 0;
+
+-- The free-text need_time can't tell preparation from cooking, so all of it
+-- becomes cooking time.
+UPDATE recipes SET
+    cook_minutes = coalesce(need_time_minutes(need_time), 0),
+    total_minutes = coalesce(need_time_minutes(need_time), 0);
+
+ALTER TABLE recipes DROP COLUMN IF EXISTS need_time;
+
+CREATE INDEX IF NOT EXISTS recipes_total_minutes_idx ON recipes(total_minutes);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS recipes_publish_at_idx;
+DROP INDEX IF EXISTS recipes_status_idx;
+ALTER TABLE recipes DROP COLUMN IF EXISTS published_at;
+ALTER TABLE recipes DROP COLUMN IF EXISTS publish_at;
+ALTER TABLE recipes DROP COLUMN IF EXISTS status;
@@ -0,0 +1,10 @@
+ALTER TABLE recipes ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'published'
+    CHECK (status IN ('draft', 'published', 'archived', 'scheduled'));
+ALTER TABLE recipes ADD COLUMN IF NOT EXISTS publish_at TIMESTAMP;
+ALTER TABLE recipes ADD COLUMN IF NOT EXISTS published_at TIMESTAMP;
+
+-- Everything written before drafts existed has already been published.
+UPDATE recipes SET published_at = created_at;
+
+CREATE INDEX IF NOT EXISTS recipes_status_idx ON recipes(status);
+CREATE INDEX IF NOT EXISTS recipes_publish_at_idx ON recipes(publish_at) WHERE status = 'scheduled';
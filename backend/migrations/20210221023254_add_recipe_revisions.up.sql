@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS recipe_revisions(
+    id INT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    recipe_id INT NOT NULL references recipes(id) ON DELETE CASCADE,
+    version INT NOT NULL,
+    snapshot JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    UNIQUE (recipe_id, version)
+);
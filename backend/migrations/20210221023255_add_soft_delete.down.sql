@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS comments_deleted_at_idx;
+DROP INDEX IF EXISTS recipes_deleted_at_idx;
+DROP INDEX IF EXISTS users_deleted_at_idx;
+ALTER TABLE comments DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE recipes DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE users DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS recipes_forked_from_idx;
+ALTER TABLE recipes DROP COLUMN IF EXISTS forked_from;
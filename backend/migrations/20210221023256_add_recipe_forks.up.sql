@@ -0,0 +1,3 @@
+ALTER TABLE recipes ADD COLUMN IF NOT EXISTS forked_from INT references recipes(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS recipes_forked_from_idx ON recipes(forked_from) WHERE forked_from IS NOT NULL;
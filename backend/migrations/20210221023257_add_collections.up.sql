@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS collections(
+    id INT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    user_id INT NOT NULL references users(id) ON DELETE CASCADE,
+    title VARCHAR(100) NOT NULL,
+    about VARCHAR(1000) NOT NULL DEFAULT '',
+    cover_url TEXT NOT NULL DEFAULT '',
+    is_public BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS collections_user_id_idx ON collections(user_id);
+
+CREATE TABLE IF NOT EXISTS collection_recipes(
+    collection_id INT NOT NULL references collections(id) ON DELETE CASCADE,
+    recipe_id INT NOT NULL references recipes(id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    PRIMARY KEY (collection_id, recipe_id)
+);
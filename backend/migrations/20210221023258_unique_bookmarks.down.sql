@@ -0,0 +1 @@
+DROP INDEX IF EXISTS bookmarks_user_id_recipe_id_idx;
@@ -0,0 +1,4 @@
+DELETE FROM bookmarks a USING bookmarks b
+    WHERE a.user_id = b.user_id AND a.recipe_id = b.recipe_id AND a.id > b.id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS bookmarks_user_id_recipe_id_idx ON bookmarks(user_id, recipe_id);
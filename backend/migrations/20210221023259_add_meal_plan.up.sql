@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS meal_plan_entries(
+    id INT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    user_id INT NOT NULL references users(id) ON DELETE CASCADE,
+    recipe_id INT NOT NULL references recipes(id) ON DELETE CASCADE,
+    date DATE NOT NULL,
+    slot VARCHAR(10) NOT NULL CHECK (slot IN ('breakfast', 'lunch', 'dinner')),
+    servings INT NOT NULL CHECK (servings > 0),
+    created_at TIMESTAMP NOT NULL,
+    UNIQUE (user_id, date, slot, recipe_id)
+);
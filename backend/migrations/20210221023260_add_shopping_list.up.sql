@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS shopping_list_items(
+    id INT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    user_id INT NOT NULL references users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    quantity DOUBLE PRECISION NOT NULL DEFAULT 0,
+    unit VARCHAR(20) NOT NULL DEFAULT '',
+    checked BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE INDEX IF NOT EXISTS shopping_list_items_user_id_idx ON shopping_list_items(user_id);
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS pantry_items(
+    id INT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    user_id INT NOT NULL references users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS pantry_items_user_id_name_idx ON pantry_items(user_id, lower(name));
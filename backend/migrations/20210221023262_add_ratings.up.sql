@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS ratings(
+    id INT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    user_id INT NOT NULL references users(id) ON DELETE CASCADE,
+    recipe_id INT NOT NULL references recipes(id) ON DELETE CASCADE,
+    rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    review TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    UNIQUE (user_id, recipe_id)
+);
+
+CREATE INDEX IF NOT EXISTS ratings_recipe_id_idx ON ratings(recipe_id);
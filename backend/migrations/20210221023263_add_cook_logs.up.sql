@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS cook_logs(
+    id INT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    user_id INT NOT NULL references users(id) ON DELETE CASCADE,
+    recipe_id INT NOT NULL references recipes(id) ON DELETE CASCADE,
+    cooked_on DATE NOT NULL,
+    note VARCHAR(500) NOT NULL DEFAULT '',
+    photo_url TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS cook_logs_recipe_id_idx ON cook_logs(recipe_id);
+CREATE INDEX IF NOT EXISTS cook_logs_user_id_idx ON cook_logs(user_id);
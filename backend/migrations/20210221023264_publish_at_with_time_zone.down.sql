@@ -0,0 +1 @@
+ALTER TABLE recipes ALTER COLUMN publish_at TYPE TIMESTAMP;
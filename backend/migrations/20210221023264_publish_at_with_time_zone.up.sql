@@ -0,0 +1,3 @@
+-- publish_at is compared with the current time, so it must keep the zone the
+-- client sent it in. Stored values are read in the time zone of the session.
+ALTER TABLE recipes ALTER COLUMN publish_at TYPE TIMESTAMPTZ;
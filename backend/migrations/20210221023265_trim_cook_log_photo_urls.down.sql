@@ -0,0 +1,2 @@
+-- The separators aren't put back, the file storage removes the photos either way.
+SELECT 1;
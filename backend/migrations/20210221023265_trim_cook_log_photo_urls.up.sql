@@ -0,0 +1,3 @@
+-- Photos of cook logs were stored with the separator the file storage puts
+-- after every url.
+UPDATE cook_logs SET photo_url = rtrim(photo_url, ';');
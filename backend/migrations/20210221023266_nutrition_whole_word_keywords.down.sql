@@ -0,0 +1,45 @@
+UPDATE nutrition_foods SET keywords = v.keywords FROM (VALUES
+    ('flour', '{flour,мук}'::TEXT[]),
+    ('sugar', '{sugar,сахар}'),
+    ('butter', '{butter,сливочн}'),
+    ('vegetable oil', '{oil,olive,масл,подсолнечн,растительн,оливков}'),
+    ('egg', '{egg,яйц,яиц}'),
+    ('milk', '{milk,молок}'),
+    ('cream', '{cream,сливк}'),
+    ('sour cream', '{сметан}'),
+    ('cheese', '{cheese,сыр}'),
+    ('cottage cheese', '{cottage,творог}'),
+    ('yogurt', '{yogurt,йогурт}'),
+    ('rice', '{rice,рис}'),
+    ('buckwheat', '{buckwheat,гречк,гречн}'),
+    ('oats', '{oat,овсян}'),
+    ('pasta', '{pasta,spaghetti,noodle,макарон,спагетти,лапш}'),
+    ('bread', '{bread,хлеб}'),
+    ('potato', '{potato,картоф}'),
+    ('onion', '{onion,лук}'),
+    ('garlic', '{garlic,чеснок}'),
+    ('carrot', '{carrot,морков}'),
+    ('tomato', '{tomato,томат,помидор}'),
+    ('cucumber', '{cucumber,огур}'),
+    ('cabbage', '{cabbage,капуст}'),
+    ('mushroom', '{mushroom,гриб,шампиньон}'),
+    ('apple', '{apple,яблок}'),
+    ('banana', '{banana,банан}'),
+    ('lemon', '{lemon,лимон}'),
+    ('chicken', '{chicken,куриц,курин}'),
+    ('beef', '{beef,говядин}'),
+    ('pork', '{pork,свинин}'),
+    ('minced meat', '{mince,фарш}'),
+    ('salmon', '{salmon,лосос,семг}'),
+    ('cod', '{cod,треск}'),
+    ('shrimp', '{shrimp,prawn,кревет}'),
+    ('beans', '{bean,фасол}'),
+    ('lentils', '{lentil,чечевиц}'),
+    ('walnuts', '{walnut,орех}'),
+    ('honey', '{honey,мед,мёд}'),
+    ('cocoa', '{cocoa,какао}'),
+    ('chocolate', '{chocolate,шоколад}'),
+    ('starch', '{starch,крахмал}'),
+    ('salt', '{salt,соль}'),
+    ('water', '{water,вод}')
+) v(name, keywords) WHERE nutrition_foods.name = v.name;
@@ -0,0 +1,47 @@
+-- Keywords are whole words or phrases of ingredient names now, the last word
+-- may take the English plural ending. Russian keywords list the usual forms.
+UPDATE nutrition_foods SET keywords = v.keywords FROM (VALUES
+    ('flour', '{flour,мука,муки,муку,мукой}'::TEXT[]),
+    ('sugar', '{sugar,сахар,сахара,сахару,сахаром}'),
+    ('butter', '{butter,сливочное,сливочного,сливочным}'),
+    ('vegetable oil', '{oil,olive,масло,масла,маслом,подсолнечное,подсолнечного,подсолнечным,растительное,растительного,растительным,оливковое,оливкового,оливковым}'),
+    ('egg', '{egg,яйцо,яйца,яиц,яйцом,яйцами}'),
+    ('milk', '{milk,молоко,молока,молоком}'),
+    ('cream', '{cream,сливки,сливок,сливками}'),
+    ('sour cream', '{"sour cream",сметана,сметаны,сметану,сметаной}'),
+    ('cheese', '{cheese,сыр,сыра,сыром,сыры}'),
+    ('cottage cheese', '{cottage,творог,творога,творогом}'),
+    ('yogurt', '{yogurt,йогурт,йогурта,йогуртом}'),
+    ('rice', '{rice,рис,риса,рисом}'),
+    ('buckwheat', '{buckwheat,гречка,гречки,гречку,гречкой,гречневая,гречневой,гречневую,гречневые,гречневых}'),
+    ('oats', '{oat,oatmeal,овсянка,овсянки,овсяные,овсяных,овсяная,овсяной,овсяную,овсяными}'),
+    ('pasta', '{pasta,spaghetti,noodle,макароны,макарон,макаронами,спагетти,лапша,лапши,лапшу,лапшой}'),
+    ('bread', '{bread,хлеб,хлеба,хлебом}'),
+    ('potato', '{potato,картофель,картофеля,картофелем,картофелина,картофелины,картофелин,картошка,картошки,картошку}'),
+    ('onion', '{onion,лук,лука,луком,луковица,луковицы,луковиц,луковицу}'),
+    ('garlic', '{garlic,чеснок,чеснока,чесноком}'),
+    ('carrot', '{carrot,морковь,моркови,морковью,морковка,морковки,морковку,морковок}'),
+    ('tomato', '{tomato,томат,томата,томаты,томатов,помидор,помидора,помидоры,помидоров}'),
+    ('cucumber', '{cucumber,огурец,огурца,огурцы,огурцов}'),
+    ('cabbage', '{cabbage,капуста,капусты,капусту,капустой}'),
+    ('mushroom', '{mushroom,гриб,грибы,грибов,грибами,шампиньон,шампиньоны,шампиньонов}'),
+    ('apple', '{apple,яблоко,яблока,яблоки,яблок}'),
+    ('banana', '{banana,банан,банана,бананы,бананов}'),
+    ('lemon', '{lemon,лимон,лимона,лимоны,лимонов}'),
+    ('chicken', '{chicken,курица,курицы,курицу,курицей,куриное,куриного,куриные,куриных,куриная,куриной,куриную,куриный}'),
+    ('beef', '{beef,говядина,говядины,говядину,говядиной}'),
+    ('pork', '{pork,свинина,свинины,свинину,свининой}'),
+    ('minced meat', '{mince,minced,фарш,фарша,фаршем}'),
+    ('salmon', '{salmon,лосось,лосося,лососем,семга,семги,семгу,сёмга,сёмги,сёмгу}'),
+    ('cod', '{cod,треска,трески,треску,треской}'),
+    ('shrimp', '{shrimp,prawn,креветка,креветки,креветок,креветками}'),
+    ('beans', '{bean,фасоль,фасоли,фасолью}'),
+    ('lentils', '{lentil,чечевица,чечевицы,чечевицу,чечевицей}'),
+    ('walnuts', '{walnut,орех,орехи,орехов,орехами,грецкие,грецких}'),
+    ('honey', '{honey,мед,меда,медом,мёд,мёда,мёдом}'),
+    ('cocoa', '{cocoa,какао}'),
+    ('chocolate', '{chocolate,шоколад,шоколада,шоколадом}'),
+    ('starch', '{starch,крахмал,крахмала,крахмалом}'),
+    ('salt', '{salt,соль,соли,солью}'),
+    ('water', '{water,вода,воды,воду,водой}')
+) v(name, keywords) WHERE nutrition_foods.name = v.name;
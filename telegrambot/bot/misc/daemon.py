@@ -37,6 +37,51 @@ async def send_messages(bot, message):
                 logger.error(e)
 
 
+def get_fork_info(message):
+    try:
+        url = environ.get("BACKEND_BASE_URL") + f"/recipe/{message['RecipeID']}"
+        recipe_url = environ.get("RECIPE_URL") + f"{message['RecipeID']}"
+        r = requests.get(url)
+        if r.status_code == 200:
+            recipe = r.json()['info']['recipe']
+            info = (f"*Ваш рецепт приготовили по\\-своему\\!*\n\n*Рецепт:* {recipe['title']}\n"
+                    f"*Автор версии:* {message['ForkerLogin']}\n" +
+                    f"_{link('Подробнее', recipe_url)}_")
+            return info
+        else:
+            logger.error("Server error")
+    except Exception as e:
+        logger.error(e)
+    return ""
+
+
+async def send_fork_message(bot, message):
+    tg_user = get.get_tg_user_id(message['AuthorID'])
+    if tg_user is None:
+        return
+    try:
+        post = get_fork_info(message)
+        if post == "":
+            return
+        await bot.send_message(chat_id=tg_user.telegram_user_id, text=post, parse_mode="MarkdownV2")
+    except Exception as e:
+        logger.error(e)
+
+
+async def consume(bot, queue, handler):
+    async with queue.iterator() as queue_iter:
+        async for message in queue_iter:
+            async with message.process():
+                msg = ast.literal_eval(message.body.decode('utf-8'))
+
+                if queue.name in message.body.decode():
+                    break
+
+                logger.info(f"Get message from {queue.name}")
+
+                await handler(bot, msg)
+
+
 async def run(bot, loop):
     counter = 10
     for i in range(1, 11):
@@ -56,22 +101,16 @@ async def run(bot, loop):
     logger.info(f"Connect to rabbit")
 
     async with connection:
-        queue_name = "new_recipe"
-
         channel: aio_pika.abc.AbstractChannel = await connection.channel()
 
         queue: aio_pika.abc.AbstractQueue = await channel.declare_queue(
-            queue_name,
+            "new_recipe",
+        )
+        fork_queue: aio_pika.abc.AbstractQueue = await channel.declare_queue(
+            "recipe_fork",
         )
 
-        async with queue.iterator() as queue_iter:
-            async for message in queue_iter:
-                async with message.process():
-                    msg = ast.literal_eval(message.body.decode('utf-8'))
-
-                    if queue.name in message.body.decode():
-                        break
-                    
-                    logger.info(f"Get message")
-
-                    await send_messages(bot, msg)
\ No newline at end of file
+        await asyncio.gather(
+            consume(bot, queue, send_messages),
+            consume(bot, fork_queue, send_fork_message),
+        )